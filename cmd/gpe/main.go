@@ -0,0 +1,65 @@
+// Command gpe is the code generator of go-persistence-extension.
+//
+//	gpe gen -interface UserQueries [-file user_queries.go] [-output user_queries_gen.go] [-entity entity.User]
+//
+// gen implements the derived query methods of an interface with gorme, see package
+// gorme/gen. It's meant to run by go generate, where -file defaults to $GOFILE.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/raaaaaaaay86/go-persistence-extension/gorme/gen"
+)
+
+func main() {
+	if len(os.Args) < 2 || os.Args[1] != "gen" {
+		fmt.Fprintln(os.Stderr, "usage: gpe gen -interface <name> [-file <file>] [-output <file>] [-entity <type>]")
+		os.Exit(2)
+	}
+
+	if err := generate(os.Args[2:]); err != nil {
+		fmt.Fprintf(os.Stderr, "gpe gen: %s\n", err)
+		os.Exit(1)
+	}
+}
+
+func generate(args []string) error {
+	flags := flag.NewFlagSet("gen", flag.ExitOnError)
+	file := flags.String("file", os.Getenv("GOFILE"), "the file declaring the interface")
+	iface := flags.String("interface", "", "the interface to implement")
+	output := flags.String("output", "", "the generated file (default <file>_gen.go)")
+	entity := flags.String("entity", "", "the entity type such as entity.User (default the entity returned by the methods)")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	if *iface == "" {
+		return fmt.Errorf("-interface is required")
+	}
+	if *file == "" {
+		return fmt.Errorf("-file is required outside go generate")
+	}
+	if *output == "" {
+		*output = strings.TrimSuffix(*file, ".go") + "_gen.go"
+	}
+	if !filepath.IsAbs(*output) {
+		*output = filepath.Join(filepath.Dir(*file), *output)
+	}
+
+	source, err := gen.NewGenerator().Generate(gen.Config{
+		Dir:       filepath.Dir(*file),
+		Interface: *iface,
+		Output:    filepath.Base(*output),
+		Entity:    *entity,
+	})
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(*output, source, 0o644)
+}
@@ -0,0 +1,67 @@
+package contract
+
+import "context"
+
+// Aggregator computes aggregates of T. The aggregated column is selected by the
+// Selector, e.g. the only non-zero field of entity as the mark based Find methods.
+// Sum, Avg, Min and Max expect a numeric column and return 0 when there is no row.
+//
+//	// SELECT SUM(age) FROM users WHERE email = 'test@mail.com'
+//	sum, err := Sum(ctx, User{Age: mark.TargetInt}, QueryMap{"email": "test@mail.com"})
+type Aggregator[T any] interface {
+	CountBy(ctx context.Context, query QueryMap) (int64, error)
+	Sum(ctx context.Context, entity Selector, query QueryMap) (float64, error)
+	Avg(ctx context.Context, entity Selector, query QueryMap) (float64, error)
+	Min(ctx context.Context, entity Selector, query QueryMap) (float64, error)
+	Max(ctx context.Context, entity Selector, query QueryMap) (float64, error)
+	GroupBy(ctx context.Context, entity Selector, query QueryMap, aggregates ...Aggregate) ([]GroupRow, error)
+	TimeSeries(ctx context.Context, entity Selector, opts BucketOptions, query QueryMap, aggregates ...Aggregate) ([]Bucket, error)
+}
+
+type AggregateFunc string
+
+const (
+	AggregateCount AggregateFunc = "COUNT"
+	AggregateSum   AggregateFunc = "SUM"
+	AggregateAvg   AggregateFunc = "AVG"
+	AggregateMin   AggregateFunc = "MIN"
+	AggregateMax   AggregateFunc = "MAX"
+)
+
+// Aggregate describes an aggregate computed for each group of GroupBy.
+// Column is either the column name or the field name of the entity, it is
+// ignored by Count.
+type Aggregate struct {
+	Func   AggregateFunc
+	Column string
+}
+
+func Count() Aggregate {
+	return Aggregate{Func: AggregateCount}
+}
+
+func SumOf(column string) Aggregate {
+	return Aggregate{Func: AggregateSum, Column: column}
+}
+
+func AvgOf(column string) Aggregate {
+	return Aggregate{Func: AggregateAvg, Column: column}
+}
+
+func MinOf(column string) Aggregate {
+	return Aggregate{Func: AggregateMin, Column: column}
+}
+
+func MaxOf(column string) Aggregate {
+	return Aggregate{Func: AggregateMax, Column: column}
+}
+
+// GroupRow is a row of GroupBy.
+//
+// - Key: the group key, which has the same type as the grouped field.
+// - Values: the aggregates in the order they were requested. NULL is returned as 0.
+// Like Aggregator, the aggregated columns must be numeric.
+type GroupRow struct {
+	Key    any
+	Values []float64
+}
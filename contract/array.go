@@ -0,0 +1,31 @@
+package contract
+
+const (
+	OpArrayContains    Operator = "array_contains"
+	OpArrayContainedBy Operator = "array_contained_by"
+	OpArrayOverlap     Operator = "array_overlap"
+	OpArrayAny         Operator = "array_any"
+)
+
+// ArrayContains matches rows whose array column contains all of the values (@>).
+//
+//	// roles @> '{admin,editor}'
+//	spec := ArrayContains("roles", "admin", "editor")
+func ArrayContains[V any](column string, values ...V) Spec {
+	return Predicate{Column: column, Operator: OpArrayContains, Value: values}
+}
+
+// ArrayContainedBy matches rows whose array column has no element other than the values (<@).
+func ArrayContainedBy[V any](column string, values ...V) Spec {
+	return Predicate{Column: column, Operator: OpArrayContainedBy, Value: values}
+}
+
+// ArrayOverlap matches rows whose array column has any of the values (&&).
+func ArrayOverlap[V any](column string, values ...V) Spec {
+	return Predicate{Column: column, Operator: OpArrayOverlap, Value: values}
+}
+
+// ArrayAny matches rows whose array column has the value (value = ANY(column)).
+func ArrayAny[V any](column string, value V) Spec {
+	return Predicate{Column: column, Operator: OpArrayAny, Value: value}
+}
@@ -0,0 +1,25 @@
+package contract
+
+// AssociationPredicate is a Spec matching the records which have at least one
+// related record of Relation matched by Spec.
+//
+// - Relation: the association field name of the entity, such as Orders. Nested associations
+// are separated by dot, e.g. Orders.Items.
+// - Spec: the Spec on the columns of the related record. Nil matches any related record.
+type AssociationPredicate struct {
+	Relation string
+	Spec     Spec
+}
+
+func (AssociationPredicate) spec() {}
+
+// Has matches the records having a related record of the relation matched by the spec.
+// Not(Has(...)) matches the records having none.
+//
+//	// users who have a paid order over 100
+//	spec := Has("Orders", And(Gt("amount", 100), Eq("status", "paid")))
+//	// users who are not in any team
+//	spec := Not(Has("Teams", nil))
+func Has(relation string, spec Spec) Spec {
+	return AssociationPredicate{Relation: relation, Spec: spec}
+}
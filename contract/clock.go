@@ -0,0 +1,138 @@
+package contract
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Clock tells the current time. The repositories read the time from a Clock
+// to resolve the relative time windows, so tests can freeze the time.
+type Clock interface {
+	Now() time.Time
+}
+
+// SystemClock is the Clock of time.Now.
+type SystemClock struct{}
+
+func (SystemClock) Now() time.Time {
+	return time.Now()
+}
+
+// FixedClock is a Clock always telling Time.
+type FixedClock struct {
+	Time time.Time
+}
+
+func (c FixedClock) Now() time.Time {
+	return c.Time
+}
+
+// Window is a time range relative to the current time. Range returns the
+// half-open range [start, end) of the window at now.
+type Window interface {
+	Range(now time.Time) (start time.Time, end time.Time, err error)
+}
+
+// RollingWindow is the duration before now.
+type RollingWindow time.Duration
+
+// Last returns the window of the duration before now, e.g. Last(7 * 24 * time.Hour)
+// for the last 7 days.
+func Last(d time.Duration) Window {
+	return RollingWindow(d)
+}
+
+func (w RollingWindow) Range(now time.Time) (time.Time, time.Time, error) {
+	if w <= 0 {
+		return time.Time{}, time.Time{}, fmt.Errorf("window duration (%s) must be positive", time.Duration(w))
+	}
+	return now.Add(-time.Duration(w)), now, nil
+}
+
+// Period is the unit of a CalendarWindow.
+type Period string
+
+const (
+	PeriodDay   Period = "day"
+	PeriodWeek  Period = "week"
+	PeriodMonth Period = "month"
+	PeriodYear  Period = "year"
+)
+
+// CalendarWindow is the calendar period containing now, shifted by Offset periods.
+// Weeks start on Monday.
+//
+// - Offset: 0 is the current period, -1 is the previous one.
+// - Location: the location deciding where a day starts. Default is the location of now.
+//
+//	// the previous month in Taipei
+//	window := CalendarWindow{Period: PeriodMonth, Offset: -1, Location: taipei}
+type CalendarWindow struct {
+	Period   Period
+	Offset   int
+	Location *time.Location
+}
+
+func (w CalendarWindow) Range(now time.Time) (time.Time, time.Time, error) {
+	if w.Location != nil {
+		now = now.In(w.Location)
+	}
+	year, month, day := now.Date()
+	loc := now.Location()
+
+	var start time.Time
+	var next func(t time.Time, n int) time.Time
+	switch w.Period {
+	case PeriodDay:
+		start = time.Date(year, month, day, 0, 0, 0, 0, loc)
+		next = func(t time.Time, n int) time.Time { return t.AddDate(0, 0, n) }
+	case PeriodWeek:
+		monday := day - (int(now.Weekday())+6)%7
+		start = time.Date(year, month, monday, 0, 0, 0, 0, loc)
+		next = func(t time.Time, n int) time.Time { return t.AddDate(0, 0, 7*n) }
+	case PeriodMonth:
+		start = time.Date(year, month, 1, 0, 0, 0, 0, loc)
+		next = func(t time.Time, n int) time.Time { return t.AddDate(0, n, 0) }
+	case PeriodYear:
+		start = time.Date(year, time.January, 1, 0, 0, 0, 0, loc)
+		next = func(t time.Time, n int) time.Time { return t.AddDate(n, 0, 0) }
+	case "":
+		return time.Time{}, time.Time{}, errors.New("no window period specified")
+	default:
+		return time.Time{}, time.Time{}, fmt.Errorf("unknown window period (%s)", w.Period)
+	}
+
+	start = next(start, w.Offset)
+	return start, next(start, 1), nil
+}
+
+// Today returns the window of the current day in loc. A nil loc is the location of the Clock.
+func Today(loc *time.Location) Window {
+	return CalendarWindow{Period: PeriodDay, Location: loc}
+}
+
+// Yesterday returns the window of the previous day in loc.
+func Yesterday(loc *time.Location) Window {
+	return CalendarWindow{Period: PeriodDay, Offset: -1, Location: loc}
+}
+
+// ThisWeek returns the window of the current week in loc.
+func ThisWeek(loc *time.Location) Window {
+	return CalendarWindow{Period: PeriodWeek, Location: loc}
+}
+
+// ThisMonth returns the window of the current month in loc.
+func ThisMonth(loc *time.Location) Window {
+	return CalendarWindow{Period: PeriodMonth, Location: loc}
+}
+
+// LastMonth returns the window of the previous month in loc.
+func LastMonth(loc *time.Location) Window {
+	return CalendarWindow{Period: PeriodMonth, Offset: -1, Location: loc}
+}
+
+// ThisYear returns the window of the current year in loc.
+func ThisYear(loc *time.Location) Window {
+	return CalendarWindow{Period: PeriodYear, Location: loc}
+}
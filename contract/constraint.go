@@ -1,6 +1,10 @@
 package contract
 
-import "golang.org/x/exp/constraints"
+import (
+	"time"
+
+	"golang.org/x/exp/constraints"
+)
 
 type Identifier interface {
 	string| constraints.Integer | constraints.Signed| constraints.Unsigned
@@ -8,4 +12,9 @@ type Identifier interface {
 
 type Number interface {
 	constraints.Integer | constraints.Signed| constraints.Unsigned | constraints.Float
-}
\ No newline at end of file
+}
+
+// Comparable are the types which can be compared with GT, GTE, LT and LTE.
+type Comparable interface {
+	Number | time.Time
+}
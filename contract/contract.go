@@ -8,83 +8,91 @@ import (
 type Ultimate[T any, Q Identifier] interface {
 	Basic[T, Q]
 	Paginated[T, Q]
+	Aggregator[T]
 }
 
 type Basic[T any, Q Identifier] interface {
 	GetBy(ctx context.Context, query QueryMap) (*T, error)
 	GetById(ctx context.Context, id Q) (*T, error)
-	FindBy(ctx context.Context, query QueryMap, limit int) ([]*T, error)
-	FindAll(ctx context.Context, limit int) ([]*T, error)
+	FindBy(ctx context.Context, query QueryMap, limit int, sorts ...Sort) ([]*T, error)
+	FindAll(ctx context.Context, limit int, sorts ...Sort) ([]*T, error)
+	ExistsBy(ctx context.Context, query QueryMap) (bool, error)
+	ExistsById(ctx context.Context, id Q) (bool, error)
+	CountBy(ctx context.Context, query QueryMap) (int64, error)
+	Find(ctx context.Context, spec Spec, opts FindOptions) ([]*T, error)
+	FindEach(ctx context.Context, spec Spec, fn func(*T) error, sorts ...Sort) error
+	FindInBatches(ctx context.Context, spec Spec, batchSize int, fn func([]*T) error, sorts ...Sort) error
+	FindStream(ctx context.Context, spec Spec, buffer int, sorts ...Sort) (<-chan *T, <-chan error)
+	FindByExample(ctx context.Context, example Example[T], limit int, sorts ...Sort) ([]*T, error)
+	GetByExample(ctx context.Context, example Example[T], sorts ...Sort) (*T, error)
+	CountByExample(ctx context.Context, example Example[T]) (int64, error)
+	ExistsByExample(ctx context.Context, example Example[T]) (bool, error)
 	Create(ctx context.Context, entity *T) error
 	Update(ctx context.Context, entity *T) (int64, error)
 	Delete(ctx context.Context, entity *T) (int64, error)
 	DeleteById(ctx context.Context, id Q) (int64, error)
-	Like(ctx context.Context, entity T, limit int) ([]*T, error)
-	FindTimeBefore(ctx context.Context, entity T, before time.Time, limit int) ([]*T, error)
-	FindTimeAfter(ctx context.Context, entity T, before time.Time, limit int) ([]*T, error)
-	FindTimeBetween(ctx context.Context, entity T, startAt time.Time, endAt time.Time, limit int) ([]*T, error)
-	FindIntGT(ctx context.Context, entity T, value int, limit int) ([]*T, error)
-	FindIntGTE(ctx context.Context, entity T, value int, limit int) ([]*T, error)
-	FindIntLT(ctx context.Context, entity T, value int, limit int) ([]*T, error)
-	FindIntLTE(ctx context.Context, entity T, value int, limit int) ([]*T, error)
-	FindUintGT(ctx context.Context, entity T, value uint, limit int) ([]*T, error)
-	FindUintGTE(ctx context.Context, entity T, value uint, limit int) ([]*T, error)
-	FindUintLT(ctx context.Context, entity T, value uint, limit int) ([]*T, error)
-	FindUintLTE(ctx context.Context, entity T, value uint, limit int) ([]*T, error)
-	FindFloat32GT(ctx context.Context, entity T, value float32, limit int) ([]*T, error)
-	FindFloat32GTE(ctx context.Context, entity T, value float32, limit int) ([]*T, error)
-	FindFloat32LT(ctx context.Context, entity T, value float32, limit int) ([]*T, error)
-	FindFloat32LTE(ctx context.Context, entity T, value float32, limit int) ([]*T, error)
-	FindFloat64GT(ctx context.Context, entity T, value float64, limit int) ([]*T, error)
-	FindFloat64GTE(ctx context.Context, entity T, value float64, limit int) ([]*T, error)
-	FindFloat64LT(ctx context.Context, entity T, value float64, limit int) ([]*T, error)
-	FindFloat64LTE(ctx context.Context, entity T, value float64, limit int) ([]*T, error)
+	Like(ctx context.Context, entity T, opts LikeOptions, limit int, sorts ...Sort) ([]*T, error)
+	FindByOperator(ctx context.Context, entity Selector, op Operator, value any, limit int, sorts ...Sort) ([]*T, error)
+	FindNull(ctx context.Context, entity Selector, limit int, sorts ...Sort) ([]*T, error)
+	FindNotNull(ctx context.Context, entity Selector, limit int, sorts ...Sort) ([]*T, error)
+	FindTimeBefore(ctx context.Context, entity Selector, before time.Time, limit int, sorts ...Sort) ([]*T, error)
+	FindTimeAfter(ctx context.Context, entity Selector, before time.Time, limit int, sorts ...Sort) ([]*T, error)
+	FindTimeBetween(ctx context.Context, entity Selector, startAt time.Time, endAt time.Time, limit int, sorts ...Sort) ([]*T, error)
+	FindTimeWithin(ctx context.Context, entity Selector, within time.Duration, limit int, sorts ...Sort) ([]*T, error)
+	FindTimeInWindow(ctx context.Context, entity Selector, window Window, limit int, sorts ...Sort) ([]*T, error)
+	FindIntGT(ctx context.Context, entity Selector, value int, limit int, sorts ...Sort) ([]*T, error)
+	FindIntGTE(ctx context.Context, entity Selector, value int, limit int, sorts ...Sort) ([]*T, error)
+	FindIntLT(ctx context.Context, entity Selector, value int, limit int, sorts ...Sort) ([]*T, error)
+	FindIntLTE(ctx context.Context, entity Selector, value int, limit int, sorts ...Sort) ([]*T, error)
+	FindUintGT(ctx context.Context, entity Selector, value uint, limit int, sorts ...Sort) ([]*T, error)
+	FindUintGTE(ctx context.Context, entity Selector, value uint, limit int, sorts ...Sort) ([]*T, error)
+	FindUintLT(ctx context.Context, entity Selector, value uint, limit int, sorts ...Sort) ([]*T, error)
+	FindUintLTE(ctx context.Context, entity Selector, value uint, limit int, sorts ...Sort) ([]*T, error)
+	FindFloat32GT(ctx context.Context, entity Selector, value float32, limit int, sorts ...Sort) ([]*T, error)
+	FindFloat32GTE(ctx context.Context, entity Selector, value float32, limit int, sorts ...Sort) ([]*T, error)
+	FindFloat32LT(ctx context.Context, entity Selector, value float32, limit int, sorts ...Sort) ([]*T, error)
+	FindFloat32LTE(ctx context.Context, entity Selector, value float32, limit int, sorts ...Sort) ([]*T, error)
+	FindFloat64GT(ctx context.Context, entity Selector, value float64, limit int, sorts ...Sort) ([]*T, error)
+	FindFloat64GTE(ctx context.Context, entity Selector, value float64, limit int, sorts ...Sort) ([]*T, error)
+	FindFloat64LT(ctx context.Context, entity Selector, value float64, limit int, sorts ...Sort) ([]*T, error)
+	FindFloat64LTE(ctx context.Context, entity Selector, value float64, limit int, sorts ...Sort) ([]*T, error)
 }
 
 type Paginated[T any, Q Identifier] interface {
-	PFindBy(ctx context.Context, query QueryMap, page int, pageSize int) (*Pagination[T], error)
-	PFindAll(ctx context.Context, page int, pageSize int) (*Pagination[T], error)
-	PFindTimeBefore(ctx context.Context, entity T, before time.Time, page int, pageSize int) (*Pagination[T], error)
-	PFindTimeAfter(ctx context.Context, entity T, before time.Time, page int, pageSize int) (*Pagination[T], error)
-	PFindTimeBetween(ctx context.Context, entity T, startAt time.Time, endAt time.Time, page int, pageSize int) (*Pagination[T], error)
-	PFindIntGT(ctx context.Context, entity T, value int, page int, pageSize int) (*Pagination[T], error)
-	PFindIntGTE(ctx context.Context, entity T, value int, page int, pageSize int) (*Pagination[T], error)
-	PFindIntLT(ctx context.Context, entity T, value int, page int, pageSize int) (*Pagination[T], error)
-	PFindIntLTE(ctx context.Context, entity T, value int, page int, pageSize int) (*Pagination[T], error)
-	PFindUintGT(ctx context.Context, entity T, value uint, page int, pageSize int) (*Pagination[T], error)
-	PFindUintGTE(ctx context.Context, entity T, value uint, page int, pageSize int) (*Pagination[T], error)
-	PFindUintLT(ctx context.Context, entity T, value uint, page int, pageSize int) (*Pagination[T], error)
-	PFindUintLTE(ctx context.Context, entity T, value uint, page int, pageSize int) (*Pagination[T], error)
-	PFindFloat32GT(ctx context.Context, entity T, value float32, page int, pageSize int) (*Pagination[T], error)
-	PFindFloat32GTE(ctx context.Context, entity T, value float32, page int, pageSize int) (*Pagination[T], error)
-	PFindFloat32LT(ctx context.Context, entity T, value float32, page int, pageSize int) (*Pagination[T], error)
-	PFindFloat32LTE(ctx context.Context, entity T, value float32, page int, pageSize int) (*Pagination[T], error)
-	PFindFloat64GT(ctx context.Context, entity T, value float64, page int, pageSize int) (*Pagination[T], error)
-	PFindFloat64GTE(ctx context.Context, entity T, value float64, page int, pageSize int) (*Pagination[T], error)
-	PFindFloat64LT(ctx context.Context, entity T, value float64, page int, pageSize int) (*Pagination[T], error)
-	PFindFloat64LTE(ctx context.Context, entity T, value float64, page int, pageSize int) (*Pagination[T], error)
-}
-
-type Pagination[T any] struct {
-	Page       int
-	PageSize   int
-	TotalPage  int
-	TotalCount int64
-	Results    []T
-}
-
-func NewPagination[T any](results []T,page int, size int, total int64) *Pagination[T] {
-	return &Pagination[T]{
-		Page:       page,
-		PageSize:   size,
-		TotalPage:  int(total) / size,
-		TotalCount: total,
-		Results:    results,
-	}
-}
-
-func (p *Pagination[T]) HasNext() bool {
-	return p.Page < p.TotalPage
+	PFindBy(ctx context.Context, query QueryMap, page int, pageSize int, sorts ...Sort) (*Pagination[T], error)
+	PFindAll(ctx context.Context, page int, pageSize int, sorts ...Sort) (*Pagination[T], error)
+	PFind(ctx context.Context, spec Spec, page int, pageSize int, sorts ...Sort) (*Pagination[T], error)
+	PFindByExample(ctx context.Context, example Example[T], page int, pageSize int, sorts ...Sort) (*Pagination[T], error)
+	PLike(ctx context.Context, entity T, opts LikeOptions, page int, pageSize int, sorts ...Sort) (*Pagination[T], error)
+	PFindByOperator(ctx context.Context, entity Selector, op Operator, value any, page int, pageSize int, sorts ...Sort) (*Pagination[T], error)
+	PFindNull(ctx context.Context, entity Selector, page int, pageSize int, sorts ...Sort) (*Pagination[T], error)
+	PFindNotNull(ctx context.Context, entity Selector, page int, pageSize int, sorts ...Sort) (*Pagination[T], error)
+	PFindTimeBefore(ctx context.Context, entity Selector, before time.Time, page int, pageSize int, sorts ...Sort) (*Pagination[T], error)
+	PFindTimeAfter(ctx context.Context, entity Selector, before time.Time, page int, pageSize int, sorts ...Sort) (*Pagination[T], error)
+	PFindTimeBetween(ctx context.Context, entity Selector, startAt time.Time, endAt time.Time, page int, pageSize int, sorts ...Sort) (*Pagination[T], error)
+	PFindTimeWithin(ctx context.Context, entity Selector, within time.Duration, page int, pageSize int, sorts ...Sort) (*Pagination[T], error)
+	PFindTimeInWindow(ctx context.Context, entity Selector, window Window, page int, pageSize int, sorts ...Sort) (*Pagination[T], error)
+	PFindIntGT(ctx context.Context, entity Selector, value int, page int, pageSize int, sorts ...Sort) (*Pagination[T], error)
+	PFindIntGTE(ctx context.Context, entity Selector, value int, page int, pageSize int, sorts ...Sort) (*Pagination[T], error)
+	PFindIntLT(ctx context.Context, entity Selector, value int, page int, pageSize int, sorts ...Sort) (*Pagination[T], error)
+	PFindIntLTE(ctx context.Context, entity Selector, value int, page int, pageSize int, sorts ...Sort) (*Pagination[T], error)
+	PFindUintGT(ctx context.Context, entity Selector, value uint, page int, pageSize int, sorts ...Sort) (*Pagination[T], error)
+	PFindUintGTE(ctx context.Context, entity Selector, value uint, page int, pageSize int, sorts ...Sort) (*Pagination[T], error)
+	PFindUintLT(ctx context.Context, entity Selector, value uint, page int, pageSize int, sorts ...Sort) (*Pagination[T], error)
+	PFindUintLTE(ctx context.Context, entity Selector, value uint, page int, pageSize int, sorts ...Sort) (*Pagination[T], error)
+	PFindFloat32GT(ctx context.Context, entity Selector, value float32, page int, pageSize int, sorts ...Sort) (*Pagination[T], error)
+	PFindFloat32GTE(ctx context.Context, entity Selector, value float32, page int, pageSize int, sorts ...Sort) (*Pagination[T], error)
+	PFindFloat32LT(ctx context.Context, entity Selector, value float32, page int, pageSize int, sorts ...Sort) (*Pagination[T], error)
+	PFindFloat32LTE(ctx context.Context, entity Selector, value float32, page int, pageSize int, sorts ...Sort) (*Pagination[T], error)
+	PFindFloat64GT(ctx context.Context, entity Selector, value float64, page int, pageSize int, sorts ...Sort) (*Pagination[T], error)
+	PFindFloat64GTE(ctx context.Context, entity Selector, value float64, page int, pageSize int, sorts ...Sort) (*Pagination[T], error)
+	PFindFloat64LT(ctx context.Context, entity Selector, value float64, page int, pageSize int, sorts ...Sort) (*Pagination[T], error)
+	PFindFloat64LTE(ctx context.Context, entity Selector, value float64, page int, pageSize int, sorts ...Sort) (*Pagination[T], error)
 }
 
+// QueryMap matches the columns of its keys with its values. A nil value, or a
+// driver.Valuer of nil such as an invalid sql.NullString, is matched by IS NULL.
+//
+//	// username = 'jordan' AND deleted_reason IS NULL
+//	query := QueryMap{"username": "jordan", "deleted_reason": nil}
 type QueryMap map[string]interface{}
\ No newline at end of file
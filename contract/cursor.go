@@ -0,0 +1,48 @@
+package contract
+
+import (
+	"context"
+	"errors"
+)
+
+// ErrInvalidCursor is returned when a cursor is malformed, tampered with, or was
+// issued for other sorts or another entity.
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+// CursorPaginated finds records page by page with keyset pagination. Unlike the
+// OFFSET of Paginated, a page starts right after the row of its cursor, so it
+// doesn't slow down on deep pages nor skip or repeat rows when rows are inserted
+// concurrently.
+//
+// An empty cursor requests the first page, the NextCursor and PrevCursor of the
+// returned page request the adjacent pages. Cursors are opaque and must be used
+// with the same sorts they were issued for. The sort columns must not be NULL.
+//
+//	page, err := CFindAll(ctx, "", 20, Desc("created_at"))
+//	for page.HasNext() {
+//		page, err = CFindAll(ctx, page.NextCursor, 20, Desc("created_at"))
+//	}
+type CursorPaginated[T any] interface {
+	CFindAll(ctx context.Context, cursor string, size int, sorts ...Sort) (*CursorPage[T], error)
+	CFindBy(ctx context.Context, query QueryMap, cursor string, size int, sorts ...Sort) (*CursorPage[T], error)
+	CFind(ctx context.Context, spec Spec, cursor string, size int, sorts ...Sort) (*CursorPage[T], error)
+}
+
+// CursorPage is a page of CursorPaginated.
+//
+// - NextCursor: the cursor of the following page, empty when there is none.
+// - PrevCursor: the cursor of the preceding page, empty when there is none.
+type CursorPage[T any] struct {
+	Size       int
+	NextCursor string
+	PrevCursor string
+	Results    []T
+}
+
+func (p *CursorPage[T]) HasNext() bool {
+	return p.NextCursor != ""
+}
+
+func (p *CursorPage[T]) HasPrev() bool {
+	return p.PrevCursor != ""
+}
@@ -0,0 +1,71 @@
+package contract
+
+// NullHandling decides how an Example matches the nil fields (pointer, slice, map)
+// of its probe.
+type NullHandling string
+
+const (
+	// NullIgnore skips the nil fields.
+	NullIgnore NullHandling = ""
+	// NullInclude matches the nil fields with IS NULL.
+	NullInclude NullHandling = "include"
+)
+
+// StringMatcher decides how an Example matches a string field. The zero value
+// matches the whole string case-sensitively, i.e. column = value.
+//
+// - Match: default is MatchExact.
+// - Case: default is CaseSensitive.
+type StringMatcher struct {
+	Match MatchMode
+	Case  CaseMode
+}
+
+// ExampleMatcher decides which fields of the probe are matched and how. A field is
+// referred by its name, its dot separated path (Model.ID) or its column name.
+//
+// - Ignored: the fields never matched.
+// - Zeros: the fields matched even when they are zero, e.g. a bool field to match false.
+// The zero value of the other non-nil fields is a wildcard.
+// - Strings: the default StringMatcher of the string fields.
+// - Fields: the StringMatcher of a string field overriding Strings.
+// - Nulls: default is NullIgnore.
+// - Combine: default is CombineAnd.
+type ExampleMatcher struct {
+	Ignored []string
+	Zeros   []string
+	Strings StringMatcher
+	Fields  map[string]StringMatcher
+	Nulls   NullHandling
+	Combine Combine
+}
+
+// Example matches the records which look like Probe according to Matcher.
+//
+//	// lower(username) LIKE lower('jor%') AND active = false
+//	example := Example[User]{
+//		Probe: User{Username: "jor", Age: 30},
+//		Matcher: ExampleMatcher{
+//			Ignored: []string{"Age"},
+//			Zeros:   []string{"Active"},
+//			Fields:  map[string]StringMatcher{"Username": {Match: MatchPrefix, Case: CaseLower}},
+//		},
+//	}
+type Example[T any] struct {
+	Probe   T
+	Matcher ExampleMatcher
+}
+
+// Spec returns the Example as a Spec, so it can be combined with other Specs.
+func (e Example[T]) Spec() Spec {
+	return ExamplePredicate{Probe: e.Probe, Matcher: e.Matcher}
+}
+
+// ExamplePredicate is the Spec of an Example. A probe without any matched field
+// matches every record.
+type ExamplePredicate struct {
+	Probe   any
+	Matcher ExampleMatcher
+}
+
+func (ExamplePredicate) spec() {}
@@ -0,0 +1,127 @@
+package contract
+
+const (
+	OpJSONContains   Operator = "json_contains"
+	OpJSONHasKey     Operator = "json_has_key"
+	OpJSONHasAnyKey  Operator = "json_has_any_key"
+	OpJSONHasAllKeys Operator = "json_has_all_keys"
+	OpJSONPathExists Operator = "json_path_exists"
+)
+
+// JSONPredicate is a leaf Spec on a JSON document column.
+//
+// - Path: the object keys or array indexes leading to the compared value. Empty means the whole document.
+// - Operator: a comparison Operator or one of the OpJSON operators. A comparison Operator compares the
+// value at Path casted by the Go type of Value, so numbers, bools and times are not compared as text.
+// - Value: same as Predicate, any JSON marshalable value for OpJSONContains, a key for OpJSONHasKey,
+// []string for OpJSONHasAnyKey/OpJSONHasAllKeys and JSONPathQuery for OpJSONPathExists.
+type JSONPredicate struct {
+	Column   string
+	Path     []string
+	Operator Operator
+	Value    any
+}
+
+// JSONPathQuery is a SQL/JSON path expression whose $name variables are bound from Vars.
+//
+//	JSONPathQuery{Path: "$.tags[*] ? (@ == $tag)", Vars: map[string]any{"tag": "vip"}}
+type JSONPathQuery struct {
+	Path string
+	Vars map[string]any
+}
+
+func (JSONPredicate) spec() {}
+
+// JSONField is the value at Path of a JSON document column. It builds the
+// JSONPredicate Specs.
+//
+//	// (settings #>> '{notification,email}')::boolean = true AND settings @> '{"theme": "dark"}'
+//	spec := And(
+//		JSON("settings", "notification", "email").Eq(true),
+//		JSON("settings").Contains(map[string]any{"theme": "dark"}),
+//	)
+type JSONField struct {
+	Column string
+	Path   []string
+}
+
+func JSON(column string, path ...string) JSONField {
+	return JSONField{Column: column, Path: path}
+}
+
+// Is builds a JSONPredicate with any operator.
+func (f JSONField) Is(op Operator, value any) Spec {
+	return JSONPredicate{Column: f.Column, Path: f.Path, Operator: op, Value: value}
+}
+
+func (f JSONField) Eq(value any) Spec {
+	return f.Is(OpEq, value)
+}
+
+func (f JSONField) Neq(value any) Spec {
+	return f.Is(OpNeq, value)
+}
+
+func (f JSONField) Gt(value any) Spec {
+	return f.Is(OpGt, value)
+}
+
+func (f JSONField) Gte(value any) Spec {
+	return f.Is(OpGte, value)
+}
+
+func (f JSONField) Lt(value any) Spec {
+	return f.Is(OpLt, value)
+}
+
+func (f JSONField) Lte(value any) Spec {
+	return f.Is(OpLte, value)
+}
+
+func (f JSONField) In(values ...any) Spec {
+	return f.Is(OpIn, values)
+}
+
+func (f JSONField) NotIn(values ...any) Spec {
+	return f.Is(OpNotIn, values)
+}
+
+// Between matches rows whose value at Path is within [start, end] inclusively.
+func (f JSONField) Between(start any, end any) Spec {
+	return f.Is(OpBetween, [2]any{start, end})
+}
+
+// IsNull matches rows whose value at Path is missing or JSON null.
+func (f JSONField) IsNull() Spec {
+	return f.Is(OpIsNull, nil)
+}
+
+func (f JSONField) IsNotNull() Spec {
+	return f.Is(OpIsNotNull, nil)
+}
+
+// Contains matches rows whose value at Path contains the JSON encoding of value (@>).
+// A json.RawMessage value is bound verbatim.
+func (f JSONField) Contains(value any) Spec {
+	return f.Is(OpJSONContains, value)
+}
+
+// HasKey matches rows whose object at Path has the top-level key (?).
+func (f JSONField) HasKey(key string) Spec {
+	return f.Is(OpJSONHasKey, key)
+}
+
+// HasAnyKey matches rows whose object at Path has any of the top-level keys (?|).
+func (f JSONField) HasAnyKey(keys ...string) Spec {
+	return f.Is(OpJSONHasAnyKey, keys)
+}
+
+// HasAllKeys matches rows whose object at Path has all of the top-level keys (?&).
+func (f JSONField) HasAllKeys(keys ...string) Spec {
+	return f.Is(OpJSONHasAllKeys, keys)
+}
+
+// PathExists matches rows for which the SQL/JSON path returns any item. vars can be nil.
+func (f JSONField) PathExists(path string, vars map[string]any) Spec {
+	return f.Is(OpJSONPathExists, JSONPathQuery{Path: path, Vars: vars})
+}
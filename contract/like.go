@@ -0,0 +1,50 @@
+package contract
+
+// MatchMode decides where the value of a Like field must appear in the column.
+// The LIKE wildcards (%, _) in the value are matched literally, except for MatchPattern.
+type MatchMode string
+
+const (
+	MatchContains MatchMode = "contains"
+	MatchPrefix   MatchMode = "prefix"
+	MatchSuffix   MatchMode = "suffix"
+	MatchExact    MatchMode = "exact"
+	// MatchPattern binds the value verbatim, wildcards must be provided by the caller.
+	MatchPattern MatchMode = "pattern"
+)
+
+// CaseMode decides how Like compares letter case.
+type CaseMode string
+
+const (
+	CaseSensitive CaseMode = ""
+	// CaseInsensitive matches with ILIKE.
+	CaseInsensitive CaseMode = "ilike"
+	// CaseLower matches lower(column) with lower(value), which can use an index on lower(column).
+	CaseLower CaseMode = "lower"
+)
+
+// Combine decides how the conditions of the Like fields are combined.
+type Combine string
+
+const (
+	CombineAnd Combine = "and"
+	CombineOr  Combine = "or"
+)
+
+// LikeOptions controls how Like and PLike match the non-zero string fields of
+// the entity. The zero value binds every field value verbatim as a case-sensitive
+// LIKE pattern, and matches every record when there is no string field.
+//
+// - Match: default is MatchPattern.
+// - Case: default is CaseSensitive.
+// - Combine: default is CombineAnd.
+//
+//	// username ILIKE '%jordan\_%' OR email ILIKE '%jordan\_%'
+//	opts := LikeOptions{Match: MatchContains, Case: CaseInsensitive, Combine: CombineOr}
+//	results, err := Like(ctx, User{Username: "jordan_", Email: "jordan_"}, opts, 10)
+type LikeOptions struct {
+	Match   MatchMode
+	Case    CaseMode
+	Combine Combine
+}
@@ -0,0 +1,127 @@
+package contract
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// PageRequest is the page, the page size and the sorts requested by a client.
+//
+//	req, err := ParsePageRequest(r.URL.Query(), PageRequestOptions{MaxSize: 100})
+//	page, err := PFindAll(ctx, req.Page, req.Size, req.Sorts...)
+type PageRequest struct {
+	Page  int
+	Size  int
+	Sorts []Sort
+}
+
+// Offset returns the number of results before the page.
+func (r PageRequest) Offset() int {
+	return (r.Page - 1) * r.Size
+}
+
+// PageRequestOptions configures ParsePageRequest.
+//
+// - PageParam, SizeParam, SortParam: the query parameters. Default is page, size and sort.
+// - DefaultSize: the size when it's not requested. Default is 20.
+// - MaxSize: a greater requested size is lowered to MaxSize. Default is no limit.
+// - DefaultSorts: the sorts when none is requested.
+// - Sortable: the columns a client can sort by. Default is any column of the entity.
+type PageRequestOptions struct {
+	PageParam    string
+	SizeParam    string
+	SortParam    string
+	DefaultSize  int
+	MaxSize      int
+	DefaultSorts []Sort
+	Sortable     []string
+}
+
+func (o PageRequestOptions) withDefaults() PageRequestOptions {
+	if o.PageParam == "" {
+		o.PageParam = "page"
+	}
+	if o.SizeParam == "" {
+		o.SizeParam = "size"
+	}
+	if o.SortParam == "" {
+		o.SortParam = "sort"
+	}
+	if o.DefaultSize <= 0 {
+		o.DefaultSize = 20
+	}
+	return o
+}
+
+// ParsePageRequest parses the page request of the query parameters values. The
+// page starts at 1. Each sort parameter is a column optionally followed by its
+// direction, asc or desc.
+//
+//	// Page 2 of 50 users ordered by age descending, then by username
+//	// ?page=2&size=50&sort=age,desc&sort=username
+//	req, err := ParsePageRequest(r.URL.Query(), PageRequestOptions{Sortable: []string{"age", "username"}})
+func ParsePageRequest(values url.Values, opts PageRequestOptions) (PageRequest, error) {
+	opts = opts.withDefaults()
+	req := PageRequest{Page: 1, Size: opts.DefaultSize, Sorts: opts.DefaultSorts}
+
+	if raw := values.Get(opts.PageParam); raw != "" {
+		page, err := strconv.Atoi(raw)
+		if err != nil || page < 1 {
+			return PageRequest{}, fmt.Errorf("%s must be a positive integer, got %q", opts.PageParam, raw)
+		}
+		req.Page = page
+	}
+
+	if raw := values.Get(opts.SizeParam); raw != "" {
+		size, err := strconv.Atoi(raw)
+		if err != nil || size < 1 {
+			return PageRequest{}, fmt.Errorf("%s must be a positive integer, got %q", opts.SizeParam, raw)
+		}
+		req.Size = size
+	}
+	if opts.MaxSize > 0 && req.Size > opts.MaxSize {
+		req.Size = opts.MaxSize
+	}
+
+	if raws := values[opts.SortParam]; len(raws) > 0 {
+		req.Sorts = nil
+		for _, raw := range raws {
+			sort, err := parseSort(raw, opts.Sortable)
+			if err != nil {
+				return PageRequest{}, fmt.Errorf("%s: %w", opts.SortParam, err)
+			}
+			req.Sorts = append(req.Sorts, sort)
+		}
+	}
+
+	return req, nil
+}
+
+func parseSort(raw string, sortable []string) (Sort, error) {
+	column, direction, _ := strings.Cut(raw, ",")
+	column = strings.TrimSpace(column)
+	if column == "" {
+		return Sort{}, fmt.Errorf("no sort column in %q", raw)
+	}
+
+	if len(sortable) > 0 {
+		allowed := false
+		for _, s := range sortable {
+			allowed = allowed || s == column
+		}
+		if !allowed {
+			return Sort{}, fmt.Errorf("column (%s) is not sortable", column)
+		}
+	}
+
+	switch strings.ToLower(strings.TrimSpace(direction)) {
+	case "", "asc":
+		return Asc(column), nil
+	case "desc":
+		return Desc(column), nil
+	default:
+		return Sort{}, fmt.Errorf("unknown sort direction (%s)", direction)
+	}
+}
@@ -0,0 +1,220 @@
+package contract
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// CountMode decides how the TotalCount of a Pagination is counted.
+//
+// - CountExact: count(*) with the same conditions as the page, in the same snapshot.
+// - CountNone: no count, TotalCount and TotalPage are -1. HasNext is still known.
+// - CountEstimate: the estimate of the query planner, which is fast on huge tables
+// but may be off after many writes. It's exact when the page is the last one.
+type CountMode string
+
+const (
+	CountExact    CountMode = ""
+	CountNone     CountMode = "none"
+	CountEstimate CountMode = "estimate"
+)
+
+// Pagination is a page of results.
+//
+// - Count: how TotalCount was counted.
+// - More: whether there are results after the page, whatever the count mode.
+type Pagination[T any] struct {
+	Page       int
+	PageSize   int
+	TotalPage  int
+	TotalCount int64
+	Count      CountMode
+	More       bool
+	Results    []T
+}
+
+// NewPagination returns an exactly counted Pagination. A negative total means
+// the total is unknown, TotalPage is then -1 too.
+func NewPagination[T any](results []T, page int, size int, total int64) *Pagination[T] {
+	p := &Pagination[T]{
+		Page:       page,
+		PageSize:   size,
+		TotalPage:  TotalPage(total, size),
+		TotalCount: total,
+		Results:    results,
+	}
+	if total < 0 {
+		p.Count = CountNone
+	}
+	p.More = p.TotalPage > 0 && page < p.TotalPage
+	return p
+}
+
+// TotalPage returns the number of pages of size holding total results, including
+// the last partial page. It's -1 if total is unknown.
+func TotalPage(total int64, size int) int {
+	if total < 0 {
+		return -1
+	}
+	if size <= 0 {
+		return 0
+	}
+	return int((total + int64(size) - 1) / int64(size))
+}
+
+func (p *Pagination[T]) HasNext() bool {
+	return p.More
+}
+
+func (p *Pagination[T]) HasPrev() bool {
+	return p.Page > 1
+}
+
+// NextPage returns the number of the next page, 0 if there is none.
+func (p *Pagination[T]) NextPage() int {
+	if !p.HasNext() {
+		return 0
+	}
+	return p.Page + 1
+}
+
+// PrevPage returns the number of the previous page, 0 if there is none.
+func (p *Pagination[T]) PrevPage() int {
+	if !p.HasPrev() {
+		return 0
+	}
+	return p.Page - 1
+}
+
+// FirstItem returns the 1-based position of the first result of the page among
+// all the results, 0 if the page is empty.
+//
+//	// Showing 21 to 40 of 95 users
+//	fmt.Printf("Showing %d to %d of %d users", p.FirstItem(), p.LastItem(), p.TotalCount)
+func (p *Pagination[T]) FirstItem() int64 {
+	if len(p.Results) == 0 {
+		return 0
+	}
+	return int64(p.Page-1)*int64(p.PageSize) + 1
+}
+
+// LastItem returns the 1-based position of the last result of the page among
+// all the results, 0 if the page is empty.
+func (p *Pagination[T]) LastItem() int64 {
+	if len(p.Results) == 0 {
+		return 0
+	}
+	return int64(p.Page-1)*int64(p.PageSize) + int64(len(p.Results))
+}
+
+// paginationJSON is the JSON representation of Pagination. Every key is always
+// present, the totals are null when they are not counted and the adjacent pages
+// are null when there is none.
+type paginationJSON[T any] struct {
+	Page       int    `json:"page"`
+	PageSize   int    `json:"page_size"`
+	TotalPage  *int   `json:"total_page"`
+	TotalCount *int64 `json:"total_count"`
+	Count      string `json:"count"`
+	HasNext    bool   `json:"has_next"`
+	HasPrev    bool   `json:"has_prev"`
+	NextPage   *int   `json:"next_page"`
+	PrevPage   *int   `json:"prev_page"`
+	FirstItem  int64  `json:"first_item"`
+	LastItem   int64  `json:"last_item"`
+	Results    []T    `json:"results"`
+}
+
+// MarshalJSON implements json.Marshaler with stable snake_case keys.
+//
+//	{"page":2,"page_size":20,"total_page":5,"total_count":95,"count":"exact","has_next":true,
+//	"has_prev":true,"next_page":3,"prev_page":1,"first_item":21,"last_item":40,"results":[...]}
+func (p Pagination[T]) MarshalJSON() ([]byte, error) {
+	v := paginationJSON[T]{
+		Page:      p.Page,
+		PageSize:  p.PageSize,
+		Count:     string(p.Count),
+		HasNext:   p.HasNext(),
+		HasPrev:   p.HasPrev(),
+		FirstItem: p.FirstItem(),
+		LastItem:  p.LastItem(),
+		Results:   p.Results,
+	}
+	if v.Count == "" {
+		v.Count = "exact"
+	}
+	if v.Results == nil {
+		v.Results = []T{}
+	}
+	if p.TotalCount >= 0 {
+		v.TotalPage, v.TotalCount = &p.TotalPage, &p.TotalCount
+	}
+	if next := p.NextPage(); next > 0 {
+		v.NextPage = &next
+	}
+	if prev := p.PrevPage(); prev > 0 {
+		v.PrevPage = &prev
+	}
+	return json.Marshal(v)
+}
+
+// UnmarshalJSON implements json.Unmarshaler, it reads the JSON of MarshalJSON.
+func (p *Pagination[T]) UnmarshalJSON(data []byte) error {
+	var v paginationJSON[T]
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+
+	*p = Pagination[T]{
+		Page:       v.Page,
+		PageSize:   v.PageSize,
+		TotalPage:  -1,
+		TotalCount: -1,
+		Count:      CountMode(v.Count),
+		More:       v.HasNext,
+		Results:    v.Results,
+	}
+	if p.Count == "exact" {
+		p.Count = CountExact
+	}
+	if v.TotalPage != nil && v.TotalCount != nil {
+		p.TotalPage, p.TotalCount = *v.TotalPage, *v.TotalCount
+	}
+	return nil
+}
+
+// LinkHeader returns the RFC 5988 Link header of the pages around p, which are
+// linked by base with the page and size parameters of opts replaced. The other
+// parameters of base, such as the sorts and the filters, are kept. The last page
+// is only linked when the total is counted exactly.
+//
+//	// <https://api.example.com/users?page=3&size=20>; rel="next", <https://api.example.com/users?page=1&size=20>; rel="prev", ...
+//	w.Header().Set("Link", page.LinkHeader(r.URL, contract.PageRequestOptions{}))
+func (p *Pagination[T]) LinkHeader(base *url.URL, opts PageRequestOptions) string {
+	opts = opts.withDefaults()
+
+	link := func(page int, rel string) string {
+		u := *base
+		query := u.Query()
+		query.Set(opts.PageParam, strconv.Itoa(page))
+		query.Set(opts.SizeParam, strconv.Itoa(p.PageSize))
+		u.RawQuery = query.Encode()
+		return fmt.Sprintf(`<%s>; rel="%s"`, u.String(), rel)
+	}
+
+	var links []string
+	if p.HasNext() {
+		links = append(links, link(p.NextPage(), "next"))
+	}
+	if p.HasPrev() {
+		links = append(links, link(p.PrevPage(), "prev"))
+	}
+	links = append(links, link(1, "first"))
+	if p.Count == CountExact && p.TotalPage > 0 {
+		links = append(links, link(p.TotalPage, "last"))
+	}
+	return strings.Join(links, ", ")
+}
@@ -0,0 +1,12 @@
+package contract
+
+import "context"
+
+// Searcher finds records by full-text search. The searched columns are the
+// non-zero string fields of entity, results are ordered by relevance.
+//
+//	// Search users which username or email matches "jordan"
+//	results, err := Search(ctx, User{Username: mark.TargetString, Email: mark.TargetString}, "jordan", 1, 10)
+type Searcher[T any] interface {
+	Search(ctx context.Context, entity T, query string, page int, pageSize int) (*Pagination[T], error)
+}
@@ -0,0 +1,21 @@
+package contract
+
+import "reflect"
+
+// Selector selects the target column of the methods such as FindIntGT, FindNull
+// or Sum. It's either an entity which only non-zero field of the expected type is
+// the target, a ColumnSelector such as gorme.Column, or the column name, which
+// type is not checked.
+//
+//	results, err := FindIntGT(ctx, User{Age: mark.TargetInt}, 22, -1)
+//	results, err := FindIntGT(ctx, gorme.Field(func(u *User) *int { return &u.Age }), 22, -1)
+//	results, err := FindIntGT(ctx, "age", 22, -1)
+type Selector any
+
+// ColumnSelector is a Selector naming its column directly. ColumnType returns the
+// Go type of the column, which the methods expecting a type such as FindIntGT
+// check, or nil to skip the check.
+type ColumnSelector interface {
+	ColumnName() string
+	ColumnType() reflect.Type
+}
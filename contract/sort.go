@@ -0,0 +1,41 @@
+package contract
+
+type Direction string
+
+const (
+	DirectionAsc  Direction = "ASC"
+	DirectionDesc Direction = "DESC"
+)
+
+type NullsOrder string
+
+const (
+	NullsDefault NullsOrder = ""
+	NullsFirst   NullsOrder = "NULLS FIRST"
+	NullsLast    NullsOrder = "NULLS LAST"
+)
+
+// Sort describes the order of a column. The column is either the column name or
+// the field name of the entity. Results are always ordered by the primary key
+// after the given sorts, so pages are deterministic.
+//
+//	// ORDER BY age DESC NULLS LAST, username ASC, id ASC
+//	results, err := FindAll(ctx, -1, Desc("age").WithNulls(NullsLast), Asc("username"))
+type Sort struct {
+	Column    string
+	Direction Direction
+	Nulls     NullsOrder
+}
+
+func Asc(column string) Sort {
+	return Sort{Column: column, Direction: DirectionAsc}
+}
+
+func Desc(column string) Sort {
+	return Sort{Column: column, Direction: DirectionDesc}
+}
+
+func (s Sort) WithNulls(nulls NullsOrder) Sort {
+	s.Nulls = nulls
+	return s
+}
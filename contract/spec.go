@@ -0,0 +1,170 @@
+package contract
+
+// Spec describes a composable query predicate. Specs are built with the leaf
+// constructors (Eq, Gt, In, Between, Like, IsNull...) and combined with And, Or
+// and Not. The persistence implementation translates a Spec into its own query
+// language.
+//
+//	// age >= 18 AND (email LIKE '%@mail.com' OR username = 'johndoe')
+//	spec := And(
+//		Gte("age", 18),
+//		Or(Like("email", "%@mail.com"), Eq("username", "johndoe")),
+//	)
+type Spec interface {
+	spec()
+}
+
+type Operator string
+
+const (
+	OpEq        Operator = "eq"
+	OpNeq       Operator = "neq"
+	OpGt        Operator = "gt"
+	OpGte       Operator = "gte"
+	OpLt        Operator = "lt"
+	OpLte       Operator = "lte"
+	OpIn        Operator = "in"
+	OpNotIn     Operator = "not_in"
+	OpBetween   Operator = "between"
+	OpLike      Operator = "like"
+	OpILike     Operator = "ilike"
+	OpPrefix    Operator = "prefix"
+	OpSuffix    Operator = "suffix"
+	OpContains  Operator = "contains"
+	OpIsNull    Operator = "is_null"
+	OpIsNotNull Operator = "is_not_null"
+)
+
+// Predicate is a leaf Spec comparing a single column with a value.
+//
+// - Value: []any for OpIn/OpNotIn, [2]any for OpBetween, nil for OpIsNull/OpIsNotNull and
+// a slice for OpArrayContains/OpArrayContainedBy/OpArrayOverlap.
+type Predicate struct {
+	Column   string
+	Operator Operator
+	Value    any
+}
+
+// Conjunction matches when all of its Specs match, so an empty one matches all rows.
+type Conjunction struct {
+	Specs []Spec
+}
+
+// Disjunction matches when any of its Specs matches, so an empty one matches no rows.
+type Disjunction struct {
+	Specs []Spec
+}
+
+// Negation matches when its Spec does not match, so the Negation of a nil Spec or
+// an empty Conjunction matches no rows.
+type Negation struct {
+	Spec Spec
+}
+
+func (Predicate) spec()   {}
+func (Conjunction) spec() {}
+func (Disjunction) spec() {}
+func (Negation) spec()    {}
+
+func And(specs ...Spec) Spec {
+	return Conjunction{Specs: specs}
+}
+
+func Or(specs ...Spec) Spec {
+	return Disjunction{Specs: specs}
+}
+
+func Not(spec Spec) Spec {
+	return Negation{Spec: spec}
+}
+
+func Eq[V any](column string, value V) Spec {
+	return Predicate{Column: column, Operator: OpEq, Value: value}
+}
+
+func Neq[V any](column string, value V) Spec {
+	return Predicate{Column: column, Operator: OpNeq, Value: value}
+}
+
+func Gt[V any](column string, value V) Spec {
+	return Predicate{Column: column, Operator: OpGt, Value: value}
+}
+
+func Gte[V any](column string, value V) Spec {
+	return Predicate{Column: column, Operator: OpGte, Value: value}
+}
+
+func Lt[V any](column string, value V) Spec {
+	return Predicate{Column: column, Operator: OpLt, Value: value}
+}
+
+func Lte[V any](column string, value V) Spec {
+	return Predicate{Column: column, Operator: OpLte, Value: value}
+}
+
+func In[V any](column string, values ...V) Spec {
+	return Predicate{Column: column, Operator: OpIn, Value: toAnySlice(values)}
+}
+
+func NotIn[V any](column string, values ...V) Spec {
+	return Predicate{Column: column, Operator: OpNotIn, Value: toAnySlice(values)}
+}
+
+// Between matches rows whose column is within [start, end] inclusively.
+func Between[V any](column string, start V, end V) Spec {
+	return Predicate{Column: column, Operator: OpBetween, Value: [2]any{start, end}}
+}
+
+// Like matches rows with the given pattern. The pattern is bound verbatim,
+// wildcards must be provided by the caller.
+func Like(column string, pattern string) Spec {
+	return Predicate{Column: column, Operator: OpLike, Value: pattern}
+}
+
+// ILike is the case-insensitive version of Like.
+func ILike(column string, pattern string) Spec {
+	return Predicate{Column: column, Operator: OpILike, Value: pattern}
+}
+
+// Prefix matches rows whose column starts with prefix. Wildcards in prefix are
+// matched literally.
+func Prefix(column string, prefix string) Spec {
+	return Predicate{Column: column, Operator: OpPrefix, Value: prefix}
+}
+
+// Suffix matches rows whose column ends with suffix. Wildcards in suffix are
+// matched literally.
+func Suffix(column string, suffix string) Spec {
+	return Predicate{Column: column, Operator: OpSuffix, Value: suffix}
+}
+
+// Contains matches rows whose column contains substr. Wildcards in substr are
+// matched literally.
+func Contains(column string, substr string) Spec {
+	return Predicate{Column: column, Operator: OpContains, Value: substr}
+}
+
+func IsNull(column string) Spec {
+	return Predicate{Column: column, Operator: OpIsNull}
+}
+
+func IsNotNull(column string) Spec {
+	return Predicate{Column: column, Operator: OpIsNotNull}
+}
+
+// FindOptions controls how the results of a Spec query are returned.
+//
+// - Limit: zero or negative means no limit.
+// - Sort: the order of the results, see Sort.
+type FindOptions struct {
+	Limit int
+	Sort  []Sort
+}
+
+func toAnySlice[V any](values []V) []any {
+	s := make([]any, len(values))
+	for i, v := range values {
+		s[i] = v
+	}
+	return s
+}
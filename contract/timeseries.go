@@ -0,0 +1,38 @@
+package contract
+
+import "time"
+
+// BucketInterval is the width of a time series bucket.
+type BucketInterval string
+
+const (
+	BucketHour  BucketInterval = "hour"
+	BucketDay   BucketInterval = "day"
+	BucketWeek  BucketInterval = "week"
+	BucketMonth BucketInterval = "month"
+)
+
+// BucketOptions describes the buckets of a time series.
+//
+// - Interval: the width of the buckets. Weeks start on Monday.
+// - Start, End: the half-open range [Start, End) of the series. Zero means unbounded.
+// - Location: the location deciding where a day starts. It must be loaded by time.LoadLocation,
+// default is UTC.
+// - Fill: whether the buckets without any row are returned with zero values. Start and End are
+// required to fill the series.
+type BucketOptions struct {
+	Interval BucketInterval
+	Start    time.Time
+	End      time.Time
+	Location *time.Location
+	Fill     bool
+}
+
+// Bucket is a bucket of a time series.
+//
+// - Start: the start time of the bucket in the Location of BucketOptions.
+// - Values: the aggregates in the order they were requested. NULL is returned as 0.
+type Bucket struct {
+	Start  time.Time
+	Values []float64
+}
@@ -0,0 +1,95 @@
+package gorme
+
+import (
+	"context"
+
+	"github.com/raaaaaaaay86/go-persistence-extension/contract"
+	"github.com/raaaaaaaay86/go-persistence-extension/gorme/macro"
+	"gorm.io/gorm"
+)
+
+var _ = contract.Aggregator[any](&AggregationRepository[any]{})
+
+type AggregationRepository[T any] struct {
+	db *gorm.DB
+}
+
+func NewAggregationRepository[T any](db *gorm.DB) *AggregationRepository[T] {
+	return &AggregationRepository[T]{db}
+}
+
+// DB implements Connector.
+func (a *AggregationRepository[T]) DB() *gorm.DB {
+	return a.db
+}
+
+// CountBy implements contract.Aggregator.
+//
+//	// Count users which age is 20
+//	count, err := CountBy(ctx, contract.QueryMap{"age": 20})
+func (a *AggregationRepository[T]) CountBy(ctx context.Context, query contract.QueryMap) (int64, error) {
+	return macro.CountBy[T](ctx, a.db, query)
+}
+
+// Sum implements contract.Aggregator.
+//
+//	// Sum the age of users which email is "test@mail.com"
+//	sum, err := Sum(ctx, User{Age: mark.TargetInt}, contract.QueryMap{"email": "test@mail.com"})
+func (a *AggregationRepository[T]) Sum(ctx context.Context, entity contract.Selector, query contract.QueryMap) (float64, error) {
+	return macro.AggregateField[T](ctx, a.db, contract.AggregateSum, entity, query)
+}
+
+// Avg implements contract.Aggregator.
+func (a *AggregationRepository[T]) Avg(ctx context.Context, entity contract.Selector, query contract.QueryMap) (float64, error) {
+	return macro.AggregateField[T](ctx, a.db, contract.AggregateAvg, entity, query)
+}
+
+// Min implements contract.Aggregator.
+func (a *AggregationRepository[T]) Min(ctx context.Context, entity contract.Selector, query contract.QueryMap) (float64, error) {
+	return macro.AggregateField[T](ctx, a.db, contract.AggregateMin, entity, query)
+}
+
+// Max implements contract.Aggregator.
+func (a *AggregationRepository[T]) Max(ctx context.Context, entity contract.Selector, query contract.QueryMap) (float64, error) {
+	return macro.AggregateField[T](ctx, a.db, contract.AggregateMax, entity, query)
+}
+
+// GroupBy implements contract.Aggregator.
+//
+//	// Count users and average their age for each email
+//	rows, err := GroupBy(ctx, User{Email: mark.TargetString}, nil, contract.Count(), contract.AvgOf("age"))
+//	for _, row := range rows {
+//		email, count, avgAge := row.Key.(string), row.Values[0], row.Values[1]
+//	}
+func (a *AggregationRepository[T]) GroupBy(
+	ctx context.Context,
+	entity contract.Selector,
+	query contract.QueryMap,
+	aggregates ...contract.Aggregate,
+) ([]contract.GroupRow, error) {
+	return macro.GroupBy[T](ctx, a.db, entity, query, aggregates...)
+}
+
+// TimeSeries implements contract.Aggregator.
+//
+//	// Count users created each day of March in Taipei, including the days without any user
+//	taipei, _ := time.LoadLocation("Asia/Taipei")
+//	buckets, err := TimeSeries(ctx, User{CreatedAt: mark.TargetTime}, contract.BucketOptions{
+//		Interval: contract.BucketDay,
+//		Start:    time.Date(2024, 3, 1, 0, 0, 0, 0, taipei),
+//		End:      time.Date(2024, 4, 1, 0, 0, 0, 0, taipei),
+//		Location: taipei,
+//		Fill:     true,
+//	}, nil)
+//	for _, bucket := range buckets {
+//		day, count := bucket.Start, bucket.Values[0]
+//	}
+func (a *AggregationRepository[T]) TimeSeries(
+	ctx context.Context,
+	entity contract.Selector,
+	opts contract.BucketOptions,
+	query contract.QueryMap,
+	aggregates ...contract.Aggregate,
+) ([]contract.Bucket, error) {
+	return macro.TimeSeries[T](ctx, a.db, entity, opts, query, aggregates...)
+}
@@ -0,0 +1,142 @@
+package gorme_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/raaaaaaaay86/go-persistence-extension/contract"
+	"github.com/raaaaaaaay86/go-persistence-extension/gorme/entity"
+	"github.com/raaaaaaaay86/go-persistence-extension/gorme/repository"
+	"github.com/raaaaaaaay86/go-persistence-extension/gorme/util"
+	"github.com/raaaaaaaay86/go-persistence-extension/mark"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/gorm"
+)
+
+type AggregationOperationTestSuite struct {
+	suite.Suite
+	UserRepository *repository.UserRepository
+}
+
+func (s *AggregationOperationTestSuite) SetupTest() {
+	if err := s.Setup(); err != nil {
+		s.T().Fatalf("failed to setup AggregationOperationTestSuite: %s", err.Error())
+	}
+}
+
+func (s *AggregationOperationTestSuite) Setup() error {
+	db, err := util.CreateGormPostgreSqlConnection(&gorm.Config{TranslateError: true})
+	if err != nil {
+		return err
+	}
+
+	s.UserRepository = repository.NewUserRepository(db.Debug())
+
+	return nil
+}
+
+func (s *AggregationOperationTestSuite) Test_CountBy() {
+	ctx := context.Background()
+
+	s.T().Log("Test_CountBy: Count users with age 20")
+	count, err := s.UserRepository.CountBy(ctx, entity.UserQueryMapper{Age: &[]int{20}[0]}.ToMap())
+	assert.NoError(s.T(), err)
+	assert.Equal(s.T(), int64(5), count)
+
+	s.T().Log("Test_CountBy: Count users with age 100")
+	count, err = s.UserRepository.CountBy(ctx, entity.UserQueryMapper{Age: &[]int{100}[0]}.ToMap())
+	assert.NoError(s.T(), err)
+	assert.Equal(s.T(), int64(0), count)
+}
+
+func (s *AggregationOperationTestSuite) Test_SumAvgMinMax() {
+	ctx := context.Background()
+	target := entity.User{Age: mark.TargetInt}
+	query := contract.QueryMap{"email": "test@mail.com"}
+
+	s.T().Log("Test_SumAvgMinMax: Min age of users")
+	min, err := s.UserRepository.Min(ctx, target, query)
+	assert.NoError(s.T(), err)
+	assert.Equal(s.T(), float64(20), min)
+
+	s.T().Log("Test_SumAvgMinMax: Max age of users")
+	max, err := s.UserRepository.Max(ctx, target, query)
+	assert.NoError(s.T(), err)
+	assert.Equal(s.T(), float64(24), max)
+
+	s.T().Log("Test_SumAvgMinMax: Sum and avg age of users with age 20")
+	sum, err := s.UserRepository.Sum(ctx, target, contract.QueryMap{"age": 20})
+	assert.NoError(s.T(), err)
+	assert.Equal(s.T(), float64(100), sum)
+
+	avg, err := s.UserRepository.Avg(ctx, target, contract.QueryMap{"age": 20})
+	assert.NoError(s.T(), err)
+	assert.Equal(s.T(), float64(20), avg)
+
+	s.T().Log("Test_SumAvgMinMax: Sum age of users without target field")
+	_, err = s.UserRepository.Sum(ctx, entity.User{}, query)
+	assert.Error(s.T(), err)
+}
+
+func (s *AggregationOperationTestSuite) Test_GroupBy() {
+	ctx := context.Background()
+
+	s.T().Log("Test_GroupBy: Count users and sum their age for each age")
+	rows, err := s.UserRepository.GroupBy(
+		ctx,
+		entity.User{Age: mark.TargetInt},
+		contract.QueryMap{"email": "test@mail.com"},
+		contract.Count(),
+		contract.SumOf("age"),
+	)
+	assert.NoError(s.T(), err)
+	assert.NotEmpty(s.T(), rows)
+	for _, row := range rows {
+		age, ok := row.Key.(int)
+		assert.True(s.T(), ok)
+		assert.Equal(s.T(), float64(age)*row.Values[0], row.Values[1])
+	}
+
+	s.T().Log("Test_GroupBy: Group users with unknown aggregate column")
+	_, err = s.UserRepository.GroupBy(ctx, entity.User{Age: mark.TargetInt}, nil, contract.SumOf("unknown"))
+	assert.Error(s.T(), err)
+}
+
+func (s *AggregationOperationTestSuite) Test_TimeSeries() {
+	ctx := context.Background()
+	target := entity.User{Birthday: mark.TargetTime}
+	opts := contract.BucketOptions{
+		Interval: contract.BucketMonth,
+		Start:    time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC),
+		End:      time.Date(2001, 1, 1, 0, 0, 0, 0, time.UTC),
+	}
+
+	s.T().Log("Test_TimeSeries: Count users born in each month of 2000")
+	buckets, err := s.UserRepository.TimeSeries(ctx, target, opts, nil)
+	assert.NoError(s.T(), err)
+	assert.Len(s.T(), buckets, 10)
+	assert.Equal(s.T(), time.March, buckets[0].Start.Month())
+	for _, bucket := range buckets {
+		assert.Equal(s.T(), 1, bucket.Start.Day())
+		assert.Equal(s.T(), float64(1), bucket.Values[0])
+	}
+
+	s.T().Log("Test_TimeSeries: Fill the months without any user")
+	opts.Fill = true
+	buckets, err = s.UserRepository.TimeSeries(ctx, target, opts, nil, contract.Count(), contract.SumOf("age"))
+	assert.NoError(s.T(), err)
+	assert.Len(s.T(), buckets, 12)
+	assert.Equal(s.T(), time.January, buckets[0].Start.Month())
+	assert.Equal(s.T(), []float64{0, 0}, buckets[0].Values)
+	assert.Equal(s.T(), []float64{1, 20}, buckets[2].Values)
+
+	s.T().Log("Test_TimeSeries: Reject filling an unbounded series")
+	_, err = s.UserRepository.TimeSeries(ctx, target, contract.BucketOptions{Interval: contract.BucketDay, Fill: true}, nil)
+	assert.Error(s.T(), err)
+}
+
+func TestAggregationOperationTestSuite(t *testing.T) {
+	suite.Run(t, new(AggregationOperationTestSuite))
+}
@@ -0,0 +1,74 @@
+package gorme
+
+import (
+	"database/sql/driver"
+	"reflect"
+	"time"
+
+	"github.com/raaaaaaaay86/go-persistence-extension/gorme/macro"
+	"gorm.io/gorm"
+	"gorm.io/gorm/schema"
+)
+
+// Array maps a PostgreSQL array column (text[], bigint[]...) to a Go slice.
+// Nil is written as NULL and NULL is read as nil.
+//
+//	type User struct {
+//		gorm.Model
+//		Roles gorme.Array[string]
+//	}
+type Array[T any] []T
+
+// Value implements driver.Valuer.
+func (a Array[T]) Value() (driver.Value, error) {
+	if a == nil {
+		return nil, nil
+	}
+	return macro.ArrayLiteral([]T(a))
+}
+
+// Scan implements sql.Scanner.
+func (a *Array[T]) Scan(src any) error {
+	if src == nil {
+		*a = nil
+		return nil
+	}
+
+	var values []T
+	if err := macro.ScanArray(src, &values); err != nil {
+		return err
+	}
+	*a = values
+	return nil
+}
+
+// GormDataType implements schema.GormDataTypeInterface.
+func (Array[T]) GormDataType() string {
+	return "array"
+}
+
+// GormDBDataType implements migrator.GormDataTypeInterface.
+func (Array[T]) GormDBDataType(db *gorm.DB, field *schema.Field) string {
+	var elem T
+	t := reflect.TypeOf(elem)
+	if t == reflect.TypeOf(time.Time{}) {
+		return "timestamptz[]"
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		return "boolean[]"
+	case reflect.Int16, reflect.Int8, reflect.Uint8:
+		return "smallint[]"
+	case reflect.Int32, reflect.Uint16:
+		return "integer[]"
+	case reflect.Int, reflect.Int64, reflect.Uint32, reflect.Uint, reflect.Uint64:
+		return "bigint[]"
+	case reflect.Float32:
+		return "real[]"
+	case reflect.Float64:
+		return "double precision[]"
+	default:
+		return "text[]"
+	}
+}
@@ -17,22 +17,28 @@ import (
 var _ = contract.Basic[any, uint](&BasicRepository[any, uint]{})
 
 type BasicRepository[T any, Q contract.Identifier] struct {
-	db *gorm.DB
+	db    *gorm.DB
+	clock contract.Clock
 }
 
-func NewBasicRepository[T any, Q contract.Identifier](db *gorm.DB) *BasicRepository[T, Q] {
-	return &BasicRepository[T, Q]{db}
+func NewBasicRepository[T any, Q contract.Identifier](db *gorm.DB, opts ...Option) *BasicRepository[T, Q] {
+	return &BasicRepository[T, Q]{db, newOptions(opts).clock}
 }
 
-func NewEagerBasicRepository[T any, Q contract.Identifier](db *gorm.DB) *BasicRepository[T, Q] {
-	return &BasicRepository[T, Q]{db.Preload(clause.Associations)}
+func NewEagerBasicRepository[T any, Q contract.Identifier](db *gorm.DB, opts ...Option) *BasicRepository[T, Q] {
+	return NewBasicRepository[T, Q](db.Preload(clause.Associations), opts...)
+}
+
+// DB implements Connector.
+func (g *BasicRepository[T, Q]) DB() *gorm.DB {
+	return g.db
 }
 
 // Create implements contract.CRUD.
 //
 // Create a new record.
 func (g *BasicRepository[T, Q]) Create(ctx context.Context, entity *T) error {
-	return g.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+	return macro.WithContext(ctx, g.db).Transaction(func(tx *gorm.DB) error {
 		return tx.Create(entity).Error
 	})
 }
@@ -44,7 +50,7 @@ func (g *BasicRepository[T, Q]) Create(ctx context.Context, entity *T) error {
 func (g *BasicRepository[T, Q]) Delete(ctx context.Context, entity *T) (int64, error) {
 	var affectedCount int64
 
-	err := g.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+	err := macro.WithContext(ctx, g.db).Transaction(func(tx *gorm.DB) error {
 		if tx := tx.Delete(entity); tx.Error != nil {
 			return tx.Error
 		} else {
@@ -64,7 +70,7 @@ func (g *BasicRepository[T, Q]) DeleteById(ctx context.Context, id Q) (int64, er
 	var entity T
 	var affectedCount int64
 
-	err := g.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+	err := macro.WithContext(ctx, g.db).Transaction(func(tx *gorm.DB) error {
 		if tx := tx.Delete(&entity, id); tx.Error != nil {
 			return tx.Error
 		} else {
@@ -87,9 +93,15 @@ func (g *BasicRepository[T, Q]) DeleteById(ctx context.Context, id Q) (int64, er
 //	results, err := FindAll(ctx, -1)
 //	// Return matched data with limit with 10
 //	results, err := FindAll(ctx, 10)
-func (g *BasicRepository[T, Q]) FindAll(ctx context.Context, limit int) ([]*T, error) {
+func (g *BasicRepository[T, Q]) FindAll(ctx context.Context, limit int, sorts ...contract.Sort) ([]*T, error) {
 	var results []*T
-	if err := g.db.WithContext(ctx).Limit(limit).Find(&results).Error; err != nil {
+
+	db, err := macro.Order[T](macro.WithContext(ctx, g.db), sorts)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := db.Limit(limit).Find(&results).Error; err != nil {
 		return nil, err
 	}
 	return results, nil
@@ -103,14 +115,166 @@ func (g *BasicRepository[T, Q]) FindAll(ctx context.Context, limit int) ([]*T, e
 //	results, err := FindBy(ctx, &user, -1)
 //	// Return matched data with limit with 10
 //	results, err := FindBy(ctx, &user ,10)
-func (g *BasicRepository[T, Q]) FindBy(ctx context.Context, query contract.QueryMap, limit int) ([]*T, error) {
+func (g *BasicRepository[T, Q]) FindBy(
+	ctx context.Context,
+	query contract.QueryMap,
+	limit int,
+	sorts ...contract.Sort,
+) ([]*T, error) {
 	var results []*T
-	if err := g.db.WithContext(ctx).Where(map[string]interface{}(query)).Limit(limit).Find(&results).Error; err != nil {
+
+	db, err := macro.Order[T](macro.WithContext(ctx, g.db), sorts)
+	if err != nil {
+		return nil, err
+	}
+
+	if db, err = macro.WhereQueryMap(db, query); err != nil {
+		return nil, err
+	}
+
+	if err := db.Limit(limit).Find(&results).Error; err != nil {
 		return nil, err
 	}
 	return results, nil
 }
 
+// ExistsBy implements contract.Basic.
+//
+// ExistsBy() reads at most one row and never loads the entity. Soft deleted
+// records are not matched.
+//
+//	// Check whether the username "jordan" is taken
+//	exists, err := ExistsBy(ctx, contract.QueryMap{"username": "jordan"})
+func (g *BasicRepository[T, Q]) ExistsBy(ctx context.Context, query contract.QueryMap) (bool, error) {
+	return macro.ExistsBy[T](ctx, g.db, query)
+}
+
+// ExistsById implements contract.Basic.
+func (g *BasicRepository[T, Q]) ExistsById(ctx context.Context, id Q) (bool, error) {
+	return macro.ExistsById[T](ctx, g.db, id)
+}
+
+// CountBy implements contract.Basic.
+//
+// CountBy() counts matched records with the same semantic as the pagination
+// total, soft deleted records are not counted.
+func (g *BasicRepository[T, Q]) CountBy(ctx context.Context, query contract.QueryMap) (int64, error) {
+	return macro.CountBy[T](ctx, g.db, query)
+}
+
+// Find implements contract.Basic.
+//
+// Find() will return records matched by the spec.
+//
+//	// Return users which age >= 18 and (email like "%@mail.com" or username = "jordan")
+//	results, err := Find(ctx, contract.And(
+//		contract.Gte("age", 18),
+//		contract.Or(contract.Like("email", "%@mail.com"), contract.Eq("username", "jordan")),
+//	), contract.FindOptions{Limit: 10})
+func (g *BasicRepository[T, Q]) Find(ctx context.Context, spec contract.Spec, opts contract.FindOptions) ([]*T, error) {
+	return macro.FindBySpec[T](ctx, g.db, spec, opts)
+}
+
+// FindEach implements contract.Basic.
+//
+// FindEach() will call fn with each record matched by the spec, without loading
+// all of them in memory. The associations are not preloaded.
+//
+//	// Export all users, fn must not query the transaction carried by ctx
+//	err := FindEach(ctx, nil, func(user *User) error {
+//		return encoder.Encode(user)
+//	}, contract.Asc("id"))
+func (g *BasicRepository[T, Q]) FindEach(
+	ctx context.Context,
+	spec contract.Spec,
+	fn func(*T) error,
+	sorts ...contract.Sort,
+) error {
+	return macro.FindEach[T](ctx, g.db, spec, fn, sorts...)
+}
+
+// FindInBatches implements contract.Basic.
+//
+// FindInBatches() will call fn with batchSize records matched by the spec at a
+// time. Each batch starts after the sort keys of the previous one, so it's as
+// fast on the last batch as on the first.
+//
+//	// Deactivate users older than 60, 500 users at a time
+//	err := FindInBatches(ctx, contract.Gt("age", 60), 500, func(users []*User) error {
+//		return deactivate(ctx, users)
+//	})
+func (g *BasicRepository[T, Q]) FindInBatches(
+	ctx context.Context,
+	spec contract.Spec,
+	batchSize int,
+	fn func([]*T) error,
+	sorts ...contract.Sort,
+) error {
+	return macro.FindInBatches[T](ctx, g.db, spec, batchSize, fn, sorts...)
+}
+
+// FindStream implements contract.Basic.
+//
+// FindStream() will send each record matched by the spec to the returned channel
+// until the end or until ctx is done. The error of the iteration, if any, is sent
+// to the error channel after the record channel is closed.
+//
+//	ctx, cancel := context.WithCancel(ctx)
+//	defer cancel()
+//	users, errs := FindStream(ctx, nil, 100)
+//	for user := range users {
+//		// ...
+//	}
+//	if err := <-errs; err != nil {
+//		return err
+//	}
+func (g *BasicRepository[T, Q]) FindStream(
+	ctx context.Context,
+	spec contract.Spec,
+	buffer int,
+	sorts ...contract.Sort,
+) (<-chan *T, <-chan error) {
+	return macro.FindStream[T](ctx, g.db, spec, buffer, sorts...)
+}
+
+// FindByExample implements contract.Basic.
+//
+// FindByExample() will return records which look like the probe of the example.
+//
+//	// Return users which username starts with "jordan" regardless of case, the age is ignored
+//	example := contract.Example[User]{
+//		Probe: User{Username: "jordan", Age: 30},
+//		Matcher: contract.ExampleMatcher{
+//			Ignored: []string{"Age"},
+//			Strings: contract.StringMatcher{Match: contract.MatchPrefix, Case: contract.CaseInsensitive},
+//		},
+//	}
+//	results, err := FindByExample(ctx, example, -1)
+func (g *BasicRepository[T, Q]) FindByExample(
+	ctx context.Context,
+	example contract.Example[T],
+	limit int,
+	sorts ...contract.Sort,
+) ([]*T, error) {
+	return macro.FindBySpec[T](ctx, g.db, example.Spec(), contract.FindOptions{Limit: limit, Sort: sorts})
+}
+
+// GetByExample implements contract.Basic.
+// GetByExample() will return the first record which looks like the probe of the example.
+func (g *BasicRepository[T, Q]) GetByExample(ctx context.Context, example contract.Example[T], sorts ...contract.Sort) (*T, error) {
+	return macro.GetBySpec[T](ctx, g.db, example.Spec(), sorts...)
+}
+
+// CountByExample implements contract.Basic.
+func (g *BasicRepository[T, Q]) CountByExample(ctx context.Context, example contract.Example[T]) (int64, error) {
+	return macro.CountBySpec[T](ctx, g.db, example.Spec())
+}
+
+// ExistsByExample implements contract.Basic.
+func (g *BasicRepository[T, Q]) ExistsByExample(ctx context.Context, example contract.Example[T]) (bool, error) {
+	return macro.ExistsBySpec[T](ctx, g.db, example.Spec())
+}
+
 // GetBy implements contract.CRUD.
 //
 // - entity: Describe the entity to be matched.
@@ -121,7 +285,13 @@ func (g *BasicRepository[T, Q]) FindBy(ctx context.Context, query contract.Query
 //	result, err := GetBy(ctx, &User{})
 func (g *BasicRepository[T, Q]) GetBy(ctx context.Context, query contract.QueryMap) (*T, error) {
 	var result T
-	if err := g.db.WithContext(ctx).Where(map[string]interface{}(query)).First(&result).Error; err != nil {
+
+	db, err := macro.WhereQueryMap(macro.WithContext(ctx, g.db), query)
+	if err != nil {
+		return &result, err
+	}
+
+	if err := db.First(&result).Error; err != nil {
 		return &result, err
 	}
 	return &result, nil
@@ -135,7 +305,7 @@ func (g *BasicRepository[T, Q]) GetBy(ctx context.Context, query contract.QueryM
 //	result, err := GetById(ctx, 10)
 func (g *BasicRepository[T, Q]) GetById(ctx context.Context, id Q) (*T, error) {
 	var result T
-	if err := g.db.First(&result, id).Error; err != nil {
+	if err := macro.WithContext(ctx, g.db).First(&result, id).Error; err != nil {
 		return &result, err
 	}
 	return &result, nil
@@ -147,7 +317,7 @@ func (g *BasicRepository[T, Q]) GetById(ctx context.Context, id Q) (*T, error) {
 func (g *BasicRepository[T, Q]) Update(ctx context.Context, entity *T) (int64, error) {
 	var affectedCount int64
 
-	err := g.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+	err := macro.WithContext(ctx, g.db).Transaction(func(tx *gorm.DB) error {
 		if tx := tx.Save(entity); tx.Error != nil {
 			return tx.Error
 		} else {
@@ -162,37 +332,60 @@ func (g *BasicRepository[T, Q]) Update(ctx context.Context, entity *T) (int64, e
 	return affectedCount, err
 }
 
-// Update implements contract.CRUD.
-// Like() will return matched records with like condition.
+// Like implements contract.Basic.
 //
-//	// Return all rows which username contains "jordan"
-//	results, err := Like(ctx, &User{Username: "jordan"})
-func (g *BasicRepository[T, Q]) Like(ctx context.Context, entity T, limit int) ([]*T, error) {
-	var results []*T
+// Like() will return records which non-zero string fields match the LIKE patterns
+// built by the options. The wildcards in the field values are escaped unless
+// opts.Match is contract.MatchPattern, which is the default.
+//
+//	// Return users which username contains "jordan"
+//	results, err := Like(ctx, User{Username: "%jordan%"}, contract.LikeOptions{}, -1)
+//	// Return users which username starts with "jordan_"
+//	results, err := Like(ctx, User{Username: "jordan_"}, contract.LikeOptions{Match: contract.MatchPrefix}, -1)
+//	// Return users which username or email contains "jordan" regardless of case
+//	opts := contract.LikeOptions{Match: contract.MatchContains, Case: contract.CaseInsensitive, Combine: contract.CombineOr}
+//	results, err := Like(ctx, User{Username: "jordan", Email: "jordan"}, opts, -1)
+func (g *BasicRepository[T, Q]) Like(
+	ctx context.Context,
+	entity T,
+	opts contract.LikeOptions,
+	limit int,
+	sorts ...contract.Sort,
+) ([]*T, error) {
+	return macro.LikeFind(ctx, g.db, entity, opts, limit, sorts...)
+}
 
-	fields, err := util.ParseNoneZeroFields(reflect.ValueOf(entity))
+// FindByOperator implements contract.Basic.
+// FindByOperator() will return records which target field matches the operator and value.
+// The target field is selected by the contract.Selector, such as the non-zero field
+// of the entity which has the same type as value, or a gorme.Column.
+//
+//	// Return users which age is in (20, 21)
+//	results, err := FindByOperator(ctx, User{Age: mark.TargetInt}, contract.OpIn, []int{20, 21}, -1)
+//	// Return users which username starts with "jordan_"
+//	results, err := FindByOperator(ctx, User{Username: mark.TargetString}, contract.OpPrefix, "jordan_", -1)
+func (g *BasicRepository[T, Q]) FindByOperator(
+	ctx context.Context,
+	entity contract.Selector,
+	op contract.Operator,
+	value any,
+	limit int,
+	sorts ...contract.Sort,
+) ([]*T, error) {
+	enum, err := macro.OperatorOf(op)
 	if err != nil {
-		return results, err
-	}
-
-	db := g.db.WithContext(ctx)
-
-	for _, field := range fields {
-		likeStr, ok := field.Value.(string)
-		if !ok {
-			continue
-		}
-		db = db.Where(fmt.Sprintf("%s LIKE ?", field.ColumnName), likeStr)
-	}
-
-	if err := db.Limit(limit).Find(&results).Error; err != nil {
-		return results, err
+		return nil, err
 	}
-
-	return results, nil
+	return macro.OperatorFind[T](ctx, g.db, entity, enum, value, limit, sorts...)
 }
 
-func (g *BasicRepository[T, Q]) FindTimeBefore(ctx context.Context, entity T, before time.Time, limit int) ([]*T, error) {
+func (g *BasicRepository[T, Q]) FindTimeBefore(
+	ctx context.Context,
+	entity contract.Selector,
+	before time.Time,
+	limit int,
+	sorts ...contract.Sort,
+) ([]*T, error) {
 	f := fmt.Sprintf
 	var results []*T
 
@@ -201,7 +394,10 @@ func (g *BasicRepository[T, Q]) FindTimeBefore(ctx context.Context, entity T, be
 		return results, err
 	}
 
-	db := g.db.WithContext(ctx)
+	db, err := macro.Order[T](macro.WithContext(ctx, g.db), sorts)
+	if err != nil {
+		return results, err
+	}
 
 	if tx := db.Where(f("%s < ?", field.ColumnName), before).Limit(limit).Find(&results); tx.Error != nil {
 		return results, tx.Error
@@ -210,7 +406,13 @@ func (g *BasicRepository[T, Q]) FindTimeBefore(ctx context.Context, entity T, be
 	return results, nil
 }
 
-func (g *BasicRepository[T, Q]) FindTimeAfter(ctx context.Context, entity T, after time.Time, limit int) ([]*T, error) {
+func (g *BasicRepository[T, Q]) FindTimeAfter(
+	ctx context.Context,
+	entity contract.Selector,
+	after time.Time,
+	limit int,
+	sorts ...contract.Sort,
+) ([]*T, error) {
 	f := fmt.Sprintf
 	var results []*T
 
@@ -219,7 +421,10 @@ func (g *BasicRepository[T, Q]) FindTimeAfter(ctx context.Context, entity T, aft
 		return results, err
 	}
 
-	db := g.db.WithContext(ctx)
+	db, err := macro.Order[T](macro.WithContext(ctx, g.db), sorts)
+	if err != nil {
+		return results, err
+	}
 
 	if tx := db.Where(f("%s > ?", field.ColumnName), after).Limit(limit).Find(&results); tx.Error != nil {
 		return results, tx.Error
@@ -230,10 +435,11 @@ func (g *BasicRepository[T, Q]) FindTimeAfter(ctx context.Context, entity T, aft
 
 func (g *BasicRepository[T, Q]) FindTimeBetween(
 	ctx context.Context,
-	entity T,
+	entity contract.Selector,
 	startAt time.Time,
 	endAt time.Time,
 	limit int,
+	sorts ...contract.Sort,
 ) ([]*T, error) {
 	f := fmt.Sprintf
 	var results []*T
@@ -243,7 +449,10 @@ func (g *BasicRepository[T, Q]) FindTimeBetween(
 		return results, err
 	}
 
-	db := g.db.WithContext(ctx)
+	db, err := macro.Order[T](macro.WithContext(ctx, g.db), sorts)
+	if err != nil {
+		return results, err
+	}
 
 	tx := db.Where(f("%s BETWEEN ? AND ?", field.ColumnName), startAt, endAt).
 		Limit(limit).
@@ -255,66 +464,122 @@ func (g *BasicRepository[T, Q]) FindTimeBetween(
 	return results, nil
 }
 
-func (g *BasicRepository[T, Q]) FindIntGT(ctx context.Context, entity T, value int, limit int) ([]*T, error) {
-	return macro.CompareFind(ctx, g.db, entity, value, operator.GT, limit)
+// FindNull implements contract.Basic.
+//
+// FindNull() will return records which target field is NULL. The target field is
+// the only non-zero field of the entity, it's usually a pointer, sql.Null* or
+// gorm.DeletedAt field. Finding by a gorm.DeletedAt field includes the soft deleted records.
+//
+//	// Return users without nickname
+//	results, err := FindNull(ctx, User{Nickname: mark.TargetPtr[string]()}, -1)
+func (g *BasicRepository[T, Q]) FindNull(ctx context.Context, entity contract.Selector, limit int, sorts ...contract.Sort) ([]*T, error) {
+	return macro.NullFind[T](ctx, g.db, entity, true, limit, sorts...)
+}
+
+// FindNotNull implements contract.Basic.
+//
+// FindNotNull() will return records which target field is not NULL.
+//
+//	// Return the soft deleted users
+//	results, err := FindNotNull(ctx, User{Model: gorm.Model{DeletedAt: mark.TargetGormDeleteAt}}, -1)
+func (g *BasicRepository[T, Q]) FindNotNull(ctx context.Context, entity contract.Selector, limit int, sorts ...contract.Sort) ([]*T, error) {
+	return macro.NullFind[T](ctx, g.db, entity, false, limit, sorts...)
+}
+
+// FindTimeWithin implements contract.Basic.
+//
+// FindTimeWithin() will return records which target time field is within the
+// duration before the time of the repository clock.
+//
+//	// Return users created in the last 7 days
+//	results, err := FindTimeWithin(ctx, User{CreatedAt: mark.TargetTime}, 7*24*time.Hour, -1)
+func (g *BasicRepository[T, Q]) FindTimeWithin(
+	ctx context.Context,
+	entity contract.Selector,
+	within time.Duration,
+	limit int,
+	sorts ...contract.Sort,
+) ([]*T, error) {
+	return macro.WindowFind[T](ctx, g.db, entity, contract.Last(within), g.clock.Now(), limit, sorts...)
+}
+
+// FindTimeInWindow implements contract.Basic.
+//
+// FindTimeInWindow() will return records which target time field is in the window
+// resolved at the time of the repository clock.
+//
+//	// Return users created this month in Taipei
+//	results, err := FindTimeInWindow(ctx, User{CreatedAt: mark.TargetTime}, contract.ThisMonth(taipei), -1)
+func (g *BasicRepository[T, Q]) FindTimeInWindow(
+	ctx context.Context,
+	entity contract.Selector,
+	window contract.Window,
+	limit int,
+	sorts ...contract.Sort,
+) ([]*T, error) {
+	return macro.WindowFind[T](ctx, g.db, entity, window, g.clock.Now(), limit, sorts...)
+}
+
+func (g *BasicRepository[T, Q]) FindIntGT(ctx context.Context, entity contract.Selector, value int, limit int, sorts ...contract.Sort) ([]*T, error) {
+	return macro.CompareFind[T](ctx, g.db, entity, value, operator.GT, limit, sorts...)
 }
 
-func (g *BasicRepository[T, Q]) FindIntGTE(ctx context.Context, entity T, value int, limit int) ([]*T, error) {
-	return macro.CompareFind(ctx, g.db, entity, value, operator.GTE, limit)
+func (g *BasicRepository[T, Q]) FindIntGTE(ctx context.Context, entity contract.Selector, value int, limit int, sorts ...contract.Sort) ([]*T, error) {
+	return macro.CompareFind[T](ctx, g.db, entity, value, operator.GTE, limit, sorts...)
 }
 
-func (g *BasicRepository[T, Q]) FindIntLT(ctx context.Context, entity T, value int, limit int) ([]*T, error) {
-	return macro.CompareFind(ctx, g.db, entity, value, operator.LT, limit)
+func (g *BasicRepository[T, Q]) FindIntLT(ctx context.Context, entity contract.Selector, value int, limit int, sorts ...contract.Sort) ([]*T, error) {
+	return macro.CompareFind[T](ctx, g.db, entity, value, operator.LT, limit, sorts...)
 }
 
-func (g *BasicRepository[T, Q]) FindIntLTE(ctx context.Context, entity T, value int, limit int) ([]*T, error) {
-	return macro.CompareFind(ctx, g.db, entity, value, operator.LTE, limit)
+func (g *BasicRepository[T, Q]) FindIntLTE(ctx context.Context, entity contract.Selector, value int, limit int, sorts ...contract.Sort) ([]*T, error) {
+	return macro.CompareFind[T](ctx, g.db, entity, value, operator.LTE, limit, sorts...)
 }
 
-func (g *BasicRepository[T, Q]) FindUintGT(ctx context.Context, entity T, value uint, limit int) ([]*T, error) {
-	return macro.CompareFind(ctx, g.db, entity, value, operator.GT, limit)
+func (g *BasicRepository[T, Q]) FindUintGT(ctx context.Context, entity contract.Selector, value uint, limit int, sorts ...contract.Sort) ([]*T, error) {
+	return macro.CompareFind[T](ctx, g.db, entity, value, operator.GT, limit, sorts...)
 }
 
-func (g *BasicRepository[T, Q]) FindUintGTE(ctx context.Context, entity T, value uint, limit int) ([]*T, error) {
-	return macro.CompareFind(ctx, g.db, entity, value, operator.GTE, limit)
+func (g *BasicRepository[T, Q]) FindUintGTE(ctx context.Context, entity contract.Selector, value uint, limit int, sorts ...contract.Sort) ([]*T, error) {
+	return macro.CompareFind[T](ctx, g.db, entity, value, operator.GTE, limit, sorts...)
 }
 
-func (g *BasicRepository[T, Q]) FindUintLT(ctx context.Context, entity T, value uint, limit int) ([]*T, error) {
-	return macro.CompareFind(ctx, g.db, entity, value, operator.LT, limit)
+func (g *BasicRepository[T, Q]) FindUintLT(ctx context.Context, entity contract.Selector, value uint, limit int, sorts ...contract.Sort) ([]*T, error) {
+	return macro.CompareFind[T](ctx, g.db, entity, value, operator.LT, limit, sorts...)
 }
 
-func (g *BasicRepository[T, Q]) FindUintLTE(ctx context.Context, entity T, value uint, limit int) ([]*T, error) {
-	return macro.CompareFind(ctx, g.db, entity, value, operator.LTE, limit)
+func (g *BasicRepository[T, Q]) FindUintLTE(ctx context.Context, entity contract.Selector, value uint, limit int, sorts ...contract.Sort) ([]*T, error) {
+	return macro.CompareFind[T](ctx, g.db, entity, value, operator.LTE, limit, sorts...)
 }
 
-func (g *BasicRepository[T, Q]) FindFloat64GT(ctx context.Context, entity T, value float64, limit int) ([]*T, error) {
-	return macro.CompareFind(ctx, g.db, entity, value, operator.GT, limit)
+func (g *BasicRepository[T, Q]) FindFloat64GT(ctx context.Context, entity contract.Selector, value float64, limit int, sorts ...contract.Sort) ([]*T, error) {
+	return macro.CompareFind[T](ctx, g.db, entity, value, operator.GT, limit, sorts...)
 }
 
-func (g *BasicRepository[T, Q]) FindFloat64GTE(ctx context.Context, entity T, value float64, limit int) ([]*T, error) {
-	return macro.CompareFind(ctx, g.db, entity, value, operator.GTE, limit)
+func (g *BasicRepository[T, Q]) FindFloat64GTE(ctx context.Context, entity contract.Selector, value float64, limit int, sorts ...contract.Sort) ([]*T, error) {
+	return macro.CompareFind[T](ctx, g.db, entity, value, operator.GTE, limit, sorts...)
 }
 
-func (g *BasicRepository[T, Q]) FindFloat64LT(ctx context.Context, entity T, value float64, limit int) ([]*T, error) {
-	return macro.CompareFind(ctx, g.db, entity, value, operator.LT, limit)
+func (g *BasicRepository[T, Q]) FindFloat64LT(ctx context.Context, entity contract.Selector, value float64, limit int, sorts ...contract.Sort) ([]*T, error) {
+	return macro.CompareFind[T](ctx, g.db, entity, value, operator.LT, limit, sorts...)
 }
 
-func (g *BasicRepository[T, Q]) FindFloat64LTE(ctx context.Context, entity T, value float64, limit int) ([]*T, error) {
-	return macro.CompareFind(ctx, g.db, entity, value, operator.LTE, limit)
+func (g *BasicRepository[T, Q]) FindFloat64LTE(ctx context.Context, entity contract.Selector, value float64, limit int, sorts ...contract.Sort) ([]*T, error) {
+	return macro.CompareFind[T](ctx, g.db, entity, value, operator.LTE, limit, sorts...)
 }
 
-func (g *BasicRepository[T, Q]) FindFloat32GT(ctx context.Context, entity T, value float32, limit int) ([]*T, error) {
-	return macro.CompareFind(ctx, g.db, entity, value, operator.GT, limit)
+func (g *BasicRepository[T, Q]) FindFloat32GT(ctx context.Context, entity contract.Selector, value float32, limit int, sorts ...contract.Sort) ([]*T, error) {
+	return macro.CompareFind[T](ctx, g.db, entity, value, operator.GT, limit, sorts...)
 }
 
-func (g *BasicRepository[T, Q]) FindFloat32GTE(ctx context.Context, entity T, value float32, limit int) ([]*T, error) {
-	return macro.CompareFind(ctx, g.db, entity, value, operator.GTE, limit)
+func (g *BasicRepository[T, Q]) FindFloat32GTE(ctx context.Context, entity contract.Selector, value float32, limit int, sorts ...contract.Sort) ([]*T, error) {
+	return macro.CompareFind[T](ctx, g.db, entity, value, operator.GTE, limit, sorts...)
 }
 
-func (g *BasicRepository[T, Q]) FindFloat32LT(ctx context.Context, entity T, value float32, limit int) ([]*T, error) {
-	return macro.CompareFind(ctx, g.db, entity, value, operator.LT, limit)
+func (g *BasicRepository[T, Q]) FindFloat32LT(ctx context.Context, entity contract.Selector, value float32, limit int, sorts ...contract.Sort) ([]*T, error) {
+	return macro.CompareFind[T](ctx, g.db, entity, value, operator.LT, limit, sorts...)
 }
 
-func (g *BasicRepository[T, Q]) FindFloat32LTE(ctx context.Context, entity T, value float32, limit int) ([]*T, error) {
-	return macro.CompareFind(ctx, g.db, entity, value, operator.LTE, limit)
+func (g *BasicRepository[T, Q]) FindFloat32LTE(ctx context.Context, entity contract.Selector, value float32, limit int, sorts ...contract.Sort) ([]*T, error) {
+	return macro.CompareFind[T](ctx, g.db, entity, value, operator.LTE, limit, sorts...)
 }
@@ -2,11 +2,15 @@ package gorme_test
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"slices"
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/raaaaaaaay86/go-persistence-extension/contract"
+	"github.com/raaaaaaaay86/go-persistence-extension/gorme"
 	"github.com/raaaaaaaay86/go-persistence-extension/gorme/entity"
 	"github.com/raaaaaaaay86/go-persistence-extension/gorme/repository"
 	"github.com/raaaaaaaay86/go-persistence-extension/gorme/util"
@@ -147,6 +151,244 @@ func (s *BasicOperationTestSuite) Test_FindBy() {
 	assert.Len(s.T(), users, 0)
 }
 
+func (s *BasicOperationTestSuite) Test_FindByFilter() {
+	ctx := context.Background()
+
+	s.T().Log("Test_FindByFilter: Find users by age between 21 and 23 and username starts with user")
+	filter := entity.UserFilter{
+		Username: &[]string{"user"}[0],
+		MinAge:   &[]int{21}[0],
+		MaxAge:   &[]int{23}[0],
+	}
+	query, err := filter.ToMap()
+	assert.NoError(s.T(), err)
+	users, err := s.UserRepository.FindBy(ctx, query, -1)
+	assert.NoError(s.T(), err)
+	assert.NotEmpty(s.T(), users)
+	for _, user := range users {
+		assert.GreaterOrEqual(s.T(), user.Age, 21)
+		assert.LessOrEqual(s.T(), user.Age, 23)
+	}
+
+	s.T().Log("Test_FindByFilter: Find users by username prefix with escaped wildcard")
+	filter = entity.UserFilter{Username: &[]string{"user_"}[0]}
+	query, err = filter.ToMap()
+	assert.NoError(s.T(), err)
+	users, err = s.UserRepository.FindBy(ctx, query, -1)
+	assert.NoError(s.T(), err)
+	assert.Len(s.T(), users, 0)
+
+	s.T().Log("Test_FindByFilter: Parse filter with invalid tag")
+	_, err = gorme.ParseFilter(struct {
+		Age *int `gpe:"op=unknown"`
+	}{Age: &[]int{1}[0]})
+	assert.Error(s.T(), err)
+	assert.Equal(s.T(), contract.QueryMap{"age": 1}, gorme.ToQueryMap(struct {
+		Age *int `gpe:"op=unknown"`
+	}{Age: &[]int{1}[0]}))
+
+	s.T().Log("Test_FindByFilter: Parse filter with two fields of the same column and operator")
+	_, err = gorme.ParseFilter(struct {
+		MinAge  *int `gpe:"column=age,op=gte"`
+		FromAge *int `gpe:"column=age,op=gte"`
+	}{MinAge: &[]int{1}[0], FromAge: &[]int{2}[0]})
+	assert.Error(s.T(), err)
+	_, err = gorme.ParseFilter(struct {
+		Age   *int
+		AgeEq *int `gpe:"column=age,op=eq"`
+	}{Age: &[]int{1}[0], AgeEq: &[]int{2}[0]})
+	assert.Error(s.T(), err)
+}
+
+func (s *BasicOperationTestSuite) Test_FindByArray() {
+	ctx := context.Background()
+
+	s.T().Log("Test_FindByArray: Find users having both admin and editor roles")
+	users, err := s.UserRepository.Find(ctx, contract.ArrayContains("roles", "admin", "editor"), contract.FindOptions{})
+	assert.NoError(s.T(), err)
+	assert.Len(s.T(), users, 1)
+	assert.ElementsMatch(s.T(), []string{"admin", "editor"}, users[0].Roles)
+
+	s.T().Log("Test_FindByArray: Find users having editor role")
+	filter := entity.UserFilter{Role: &[]string{"editor"}[0]}
+	query, err := filter.ToMap()
+	assert.NoError(s.T(), err)
+	users, err = s.UserRepository.FindBy(ctx, query, -1)
+	assert.NoError(s.T(), err)
+	assert.Len(s.T(), users, 3)
+	for _, user := range users {
+		assert.Contains(s.T(), user.Roles, "editor")
+	}
+
+	s.T().Log("Test_FindByArray: Find users having admin or viewer role")
+	filter = entity.UserFilter{AnyRoles: []string{"admin", "viewer"}}
+	query, err = filter.ToMap()
+	assert.NoError(s.T(), err)
+	users, err = s.UserRepository.FindBy(ctx, query, -1)
+	assert.NoError(s.T(), err)
+	assert.Len(s.T(), users, 2)
+
+	s.T().Log("Test_FindByArray: Find users whose roles are only editor")
+	users, err = s.UserRepository.FindByOperator(
+		ctx,
+		entity.User{Roles: gorme.Array[string]{mark.TargetString}},
+		contract.OpArrayContainedBy,
+		[]string{"editor"},
+		-1,
+	)
+	assert.NoError(s.T(), err)
+	for _, user := range users {
+		assert.NotContains(s.T(), user.Roles, "admin")
+	}
+
+	s.T().Log("Test_FindByArray: Find users by roles equality")
+	users, err = s.UserRepository.FindBy(ctx, entity.UserQueryMapper{Roles: &gorme.Array[string]{"editor"}}.ToMap(), -1)
+	assert.NoError(s.T(), err)
+	assert.Len(s.T(), users, 2)
+}
+
+func (s *BasicOperationTestSuite) Test_FindByExample() {
+	ctx := context.Background()
+
+	s.T().Log("Test_FindByExample: Find users which username starts with USER1 regardless of case, ignoring age")
+	users, err := s.UserRepository.FindByExample(ctx, contract.Example[entity.User]{
+		Probe: entity.User{Username: "USER1", Age: 99},
+		Matcher: contract.ExampleMatcher{
+			Ignored: []string{"Age"},
+			Strings: contract.StringMatcher{Match: contract.MatchPrefix, Case: contract.CaseInsensitive},
+		},
+	}, -1, contract.Asc("username"))
+	assert.NoError(s.T(), err)
+	assert.Len(s.T(), users, 2)
+	for _, user := range users {
+		assert.True(s.T(), strings.HasPrefix(user.Username, "user1"))
+	}
+
+	s.T().Log("Test_FindByExample: Match the zero age")
+	count, err := s.UserRepository.CountByExample(ctx, contract.Example[entity.User]{
+		Matcher: contract.ExampleMatcher{Zeros: []string{"age"}},
+	})
+	assert.NoError(s.T(), err)
+	assert.Equal(s.T(), int64(0), count)
+
+	s.T().Log("Test_FindByExample: Find users which username is user2 or age is 24")
+	users, err = s.UserRepository.FindByExample(ctx, contract.Example[entity.User]{
+		Probe:   entity.User{Username: "user2", Age: 24},
+		Matcher: contract.ExampleMatcher{Combine: contract.CombineOr},
+	}, -1)
+	assert.NoError(s.T(), err)
+	assert.Len(s.T(), users, 2)
+
+	s.T().Log("Test_FindByExample: Get user3 by exact username")
+	user, err := s.UserRepository.GetByExample(ctx, contract.Example[entity.User]{Probe: entity.User{Username: "user3"}})
+	assert.NoError(s.T(), err)
+	assert.Equal(s.T(), "user3", user.Username)
+
+	s.T().Log("Test_FindByExample: Find users with the nil fields matched by IS NULL, skipping the associations")
+	users, err = s.UserRepository.FindByExample(ctx, contract.Example[entity.User]{
+		Probe:   entity.User{Orders: []entity.Order{{Amount: 5}}},
+		Matcher: contract.ExampleMatcher{Nulls: contract.NullInclude},
+	}, -1)
+	assert.NoError(s.T(), err)
+	for _, user := range users {
+		assert.Nil(s.T(), user.Nickname)
+	}
+
+	s.T().Log("Test_FindByExample: Reject unknown matcher field")
+	_, err = s.UserRepository.ExistsByExample(ctx, contract.Example[entity.User]{
+		Matcher: contract.ExampleMatcher{Ignored: []string{"Nmae"}},
+	})
+	assert.Error(s.T(), err)
+}
+
+func (s *BasicOperationTestSuite) Test_FindByAssociation() {
+	ctx := context.Background()
+
+	s.T().Log("Test_FindByAssociation: Find users who have a paid order over 100")
+	users, err := s.UserRepository.Find(ctx, contract.Has("Orders", contract.And(
+		contract.Gt("amount", 100),
+		contract.Eq("status", "paid"),
+	)), contract.FindOptions{})
+	assert.NoError(s.T(), err)
+	assert.Len(s.T(), users, 1)
+	assert.Equal(s.T(), "user1", users[0].Username)
+
+	s.T().Log("Test_FindByAssociation: Count users who have an order over 100")
+	count, err := gorme.CountBySpec[entity.User](ctx, s.UserRepository, contract.Has("Orders", contract.Gt("amount", 100)))
+	assert.NoError(s.T(), err)
+	assert.Equal(s.T(), int64(2), count)
+
+	s.T().Log("Test_FindByAssociation: Find users in the backend team")
+	users, err = s.UserRepository.Find(ctx, contract.Has("Teams", contract.Eq("name", "backend")), contract.FindOptions{})
+	assert.NoError(s.T(), err)
+	assert.Len(s.T(), users, 2)
+
+	s.T().Log("Test_FindByAssociation: Count users not in any team")
+	count, err = gorme.CountBySpec[entity.User](ctx, s.UserRepository, contract.Not(contract.Has("Teams", nil)))
+	assert.NoError(s.T(), err)
+	assert.Equal(s.T(), int64(8), count)
+
+	s.T().Log("Test_FindByAssociation: Find users which profile theme is dark")
+	users, err = s.UserRepository.Find(ctx, contract.Has("Profile", contract.JSON("settings", "theme").Eq("dark")), contract.FindOptions{})
+	assert.NoError(s.T(), err)
+	assert.Len(s.T(), users, 2)
+	for _, user := range users {
+		assert.Equal(s.T(), "dark", user.Profile.Settings.Data.Theme)
+	}
+
+	s.T().Log("Test_FindByAssociation: Reject unknown relation")
+	_, err = s.UserRepository.Find(ctx, contract.Has("Nope", nil), contract.FindOptions{})
+	assert.Error(s.T(), err)
+
+	s.T().Log("Test_FindByAssociation: Reject a column which the related table doesn't have")
+	_, err = s.UserRepository.Find(ctx, contract.Has("Orders", contract.Gt("age", 20)), contract.FindOptions{})
+	assert.Error(s.T(), err)
+}
+
+func (s *BasicOperationTestSuite) Test_FindNull() {
+	ctx := context.Background()
+
+	s.T().Log("Test_FindNull: Find users without nickname")
+	users, err := s.UserRepository.FindNull(ctx, entity.User{Nickname: mark.TargetPtr[string]()}, -1)
+	assert.NoError(s.T(), err)
+	assert.Len(s.T(), users, 8)
+	for _, user := range users {
+		assert.Nil(s.T(), user.Nickname)
+	}
+
+	s.T().Log("Test_FindNull: Find users with nickname")
+	users, err = s.UserRepository.FindNotNull(ctx, entity.User{Nickname: mark.TargetPtr[string]()}, -1, contract.Asc("username"))
+	assert.NoError(s.T(), err)
+	assert.Len(s.T(), users, 2)
+	assert.Equal(s.T(), "one", *users[0].Nickname)
+
+	s.T().Log("Test_FindNull: Find users by a nil QueryMap value")
+	count, err := s.UserRepository.CountBy(ctx, contract.QueryMap{"nickname": nil, "age": 20})
+	assert.NoError(s.T(), err)
+	assert.Equal(s.T(), int64(4), count)
+
+	s.T().Log("Test_FindNull: Find the soft deleted users")
+	rollback := errors.New("rollback")
+	err = gorme.Transaction(ctx, s.UserRepository, func(ctx context.Context) error {
+		user, err := s.UserRepository.GetBy(ctx, contract.QueryMap{"username": "user3"})
+		assert.NoError(s.T(), err)
+		_, err = s.UserRepository.Delete(ctx, user)
+		assert.NoError(s.T(), err)
+
+		deleted := entity.User{Model: gorm.Model{DeletedAt: mark.TargetGormDeleteAt}}
+		users, err := s.UserRepository.FindNotNull(ctx, deleted, -1)
+		assert.NoError(s.T(), err)
+		assert.Len(s.T(), users, 1)
+		assert.Equal(s.T(), "user3", users[0].Username)
+
+		pagination, err := s.UserRepository.PFindNull(ctx, deleted, 1, 20)
+		assert.NoError(s.T(), err)
+		assert.Equal(s.T(), int64(9), pagination.TotalCount)
+		return rollback
+	})
+	assert.ErrorIs(s.T(), err, rollback)
+}
+
 func (s *BasicOperationTestSuite) Test_FindByAll() {
 	ctx := context.Background()
 
@@ -160,6 +402,72 @@ func (s *BasicOperationTestSuite) Test_FindByAll() {
 	assert.Len(s.T(), users, 1)
 }
 
+func (s *BasicOperationTestSuite) Test_FindAllSorted() {
+	ctx := context.Background()
+
+	s.T().Log("Test_FindAllSorted: Find all users ordered by age desc")
+	users, err := s.UserRepository.FindAll(ctx, -1, contract.Desc("age"))
+	assert.NoError(s.T(), err)
+	for i := 1; i < len(users); i++ {
+		assert.GreaterOrEqual(s.T(), users[i-1].Age, users[i].Age)
+		if users[i-1].Age == users[i].Age {
+			assert.Less(s.T(), users[i-1].ID, users[i].ID)
+		}
+	}
+
+	s.T().Log("Test_FindAllSorted: Find all users ordered by unknown column")
+	_, err = s.UserRepository.FindAll(ctx, -1, contract.Asc("unknown"))
+	assert.Error(s.T(), err)
+}
+
+func (s *BasicOperationTestSuite) Test_ExistsBy() {
+	ctx := context.Background()
+
+	s.T().Log("Test_ExistsBy: Check existing username")
+	exists, err := s.UserRepository.ExistsBy(ctx, entity.UserQueryMapper{Username: &[]string{"user1"}[0]}.ToMap())
+	assert.NoError(s.T(), err)
+	assert.True(s.T(), exists)
+
+	s.T().Log("Test_ExistsBy: Check non-exist username")
+	exists, err = s.UserRepository.ExistsBy(ctx, entity.UserQueryMapper{Username: &[]string{"non-exist"}[0]}.ToMap())
+	assert.NoError(s.T(), err)
+	assert.False(s.T(), exists)
+}
+
+func (s *BasicOperationTestSuite) Test_ExistsByIdAndCountBy() {
+	ctx := context.Background()
+
+	s.T().Log("Test_ExistsByIdAndCountBy: Create user")
+	user := entity.User{
+		Username: fmt.Sprintf("exists_by_id_%d", time.Now().Unix()),
+		Email:    "exists_by_id@mail.com",
+		Birthday: time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC),
+		Age:      10,
+	}
+	err := s.UserRepository.Create(ctx, &user)
+	assert.NoError(s.T(), err)
+
+	exists, err := s.UserRepository.ExistsById(ctx, user.ID)
+	assert.NoError(s.T(), err)
+	assert.True(s.T(), exists)
+
+	count, err := s.UserRepository.CountBy(ctx, contract.QueryMap{"email": user.Email})
+	assert.NoError(s.T(), err)
+	assert.Equal(s.T(), int64(1), count)
+
+	s.T().Log("Test_ExistsByIdAndCountBy: Soft deleted user should not be matched")
+	_, err = s.UserRepository.DeleteById(ctx, user.ID)
+	assert.NoError(s.T(), err)
+
+	exists, err = s.UserRepository.ExistsById(ctx, user.ID)
+	assert.NoError(s.T(), err)
+	assert.False(s.T(), exists)
+
+	count, err = s.UserRepository.CountBy(ctx, contract.QueryMap{"email": user.Email})
+	assert.NoError(s.T(), err)
+	assert.Equal(s.T(), int64(0), count)
+}
+
 func (s *BasicOperationTestSuite) Test_CreateAndDeleteById() {
 	ctx := context.Background()
 
@@ -227,15 +535,61 @@ func (s *BasicOperationTestSuite) Test_CreateAndUpdateByStruct() {
 
 func (s *BasicOperationTestSuite) Test_Like() {
 	s.T().Log("Test_Like: Find users by username")
-	users, err := s.UserRepository.Like(context.Background(), entity.User{Username: "%user%"}, -1)
+	users, err := s.UserRepository.Like(context.Background(), entity.User{Username: "%user%"}, contract.LikeOptions{}, -1)
 	assert.NoError(s.T(), err)
 	assert.Len(s.T(), users, 10)
 
 	s.T().Log("Test_Like: Find users by username and email")
 	limit := 5
-	users, err = s.UserRepository.Like(context.Background(), entity.User{Username: "%user%", Email: "%mail%"}, limit)
+	users, err = s.UserRepository.Like(context.Background(), entity.User{Username: "%user%", Email: "%mail%"}, contract.LikeOptions{}, limit)
 	assert.NoError(s.T(), err)
 	assert.Len(s.T(), users, limit)
+
+	ctx := context.Background()
+
+	s.T().Log("Test_Like: Find users by username bound verbatim")
+	users, err = s.UserRepository.Like(ctx, entity.User{Username: "user"}, contract.LikeOptions{}, -1)
+	assert.NoError(s.T(), err)
+	assert.Empty(s.T(), users)
+
+	s.T().Log("Test_Like: Find users by username with escaped wildcard")
+	users, err = s.UserRepository.Like(ctx, entity.User{Username: "user_"}, contract.LikeOptions{Match: contract.MatchPrefix}, -1)
+	assert.NoError(s.T(), err)
+	assert.Len(s.T(), users, 0)
+
+	s.T().Log("Test_Like: Find users by exact username regardless of case")
+	opts := contract.LikeOptions{Match: contract.MatchExact, Case: contract.CaseInsensitive}
+	users, err = s.UserRepository.Like(ctx, entity.User{Username: "USER1"}, opts, -1)
+	assert.NoError(s.T(), err)
+	assert.Len(s.T(), users, 1)
+	assert.Equal(s.T(), "user1", users[0].Username)
+
+	s.T().Log("Test_Like: Find users by lower case suffix")
+	opts = contract.LikeOptions{Match: contract.MatchSuffix, Case: contract.CaseLower}
+	users, err = s.UserRepository.Like(ctx, entity.User{Email: "@MAIL.COM"}, opts, -1)
+	assert.NoError(s.T(), err)
+	assert.Len(s.T(), users, 10)
+
+	s.T().Log("Test_Like: Find users matching username or email")
+	opts = contract.LikeOptions{Match: contract.MatchExact, Combine: contract.CombineOr}
+	users, err = s.UserRepository.Like(ctx, entity.User{Username: "user2", Email: "non-exist"}, opts, -1)
+	assert.NoError(s.T(), err)
+	assert.Len(s.T(), users, 1)
+
+	s.T().Log("Test_Like: Find users with a caller provided pattern")
+	opts = contract.LikeOptions{Match: contract.MatchPattern}
+	users, err = s.UserRepository.Like(ctx, entity.User{Username: "user_"}, opts, -1)
+	assert.NoError(s.T(), err)
+	assert.Len(s.T(), users, 9)
+
+	s.T().Log("Test_Like: Find users without like field")
+	users, err = s.UserRepository.Like(ctx, entity.User{Age: 20}, contract.LikeOptions{}, -1)
+	assert.NoError(s.T(), err)
+	assert.Len(s.T(), users, 10)
+
+	s.T().Log("Test_Like: Find users without like field by opted-in options")
+	_, err = s.UserRepository.Like(ctx, entity.User{Age: 20}, contract.LikeOptions{Match: contract.MatchContains}, -1)
+	assert.Error(s.T(), err)
 }
 
 func (s *BasicOperationTestSuite) Test_FindTimeBefore() {
@@ -367,6 +721,257 @@ func (s *BasicOperationTestSuite) Test_FindIntLTE() {
 	}
 }
 
+func (s *BasicOperationTestSuite) Test_Find() {
+	ctx := context.Background()
+
+	s.T().Log("Test_Find: Find users which age >= 23 or username is user1")
+	spec := contract.Or(
+		contract.Gte("age", 23),
+		contract.Eq("username", "user1"),
+	)
+	users, err := s.UserRepository.Find(ctx, spec, contract.FindOptions{})
+	assert.NoError(s.T(), err)
+	assert.NotEmpty(s.T(), users)
+	for _, user := range users {
+		assert.True(s.T(), user.Age >= 23 || user.Username == "user1")
+	}
+
+	s.T().Log("Test_Find: Find users which age is 20 and username is not in (user1, user3) with limit 2")
+	spec = contract.And(
+		contract.Eq("age", 20),
+		contract.Not(contract.In("username", "user1", "user3")),
+	)
+	users, err = s.UserRepository.Find(ctx, spec, contract.FindOptions{Limit: 2})
+	assert.NoError(s.T(), err)
+	assert.Len(s.T(), users, 2)
+	for _, user := range users {
+		assert.Equal(s.T(), 20, user.Age)
+		assert.NotContains(s.T(), []string{"user1", "user3"}, user.Username)
+	}
+
+	s.T().Log("Test_Find: Find no users by an empty Or and the Not of no condition")
+	for _, spec := range []contract.Spec{
+		contract.Or(),
+		contract.Not(contract.And()),
+		contract.Not(nil),
+		contract.And(contract.Gte("age", 20), contract.Or()),
+	} {
+		users, err = s.UserRepository.Find(ctx, spec, contract.FindOptions{})
+		assert.NoError(s.T(), err)
+		assert.Empty(s.T(), users)
+	}
+
+	s.T().Log("Test_Find: Find all users by an Or of no condition")
+	all, err := s.UserRepository.FindAll(ctx, -1)
+	assert.NoError(s.T(), err)
+	users, err = s.UserRepository.Find(ctx, contract.Or(contract.And(), contract.Eq("age", 20)), contract.FindOptions{})
+	assert.NoError(s.T(), err)
+	assert.Len(s.T(), users, len(all))
+
+	s.T().Log("Test_Find: Find users with unsupported operator")
+	_, err = s.UserRepository.Find(ctx, contract.Predicate{Column: "age", Operator: "unknown"}, contract.FindOptions{})
+	assert.Error(s.T(), err)
+}
+
+func (s *BasicOperationTestSuite) Test_FindEach() {
+	ctx := context.Background()
+	spec := contract.Gte("age", 21)
+
+	expected, err := s.UserRepository.Find(ctx, spec, contract.FindOptions{Sort: []contract.Sort{contract.Desc("age")}})
+	assert.NoError(s.T(), err)
+	assert.NotEmpty(s.T(), expected)
+
+	s.T().Log("Test_FindEach: Iterate users with age >= 21 one by one")
+	var ids []uint
+	err = s.UserRepository.FindEach(ctx, spec, func(user *entity.User) error {
+		ids = append(ids, user.ID)
+		return nil
+	}, contract.Desc("age"))
+	assert.NoError(s.T(), err)
+	assert.Len(s.T(), ids, len(expected))
+	for i, user := range expected {
+		assert.Equal(s.T(), user.ID, ids[i])
+	}
+
+	s.T().Log("Test_FindEach: Stop at the first error")
+	stop := errors.New("stop")
+	count := 0
+	err = s.UserRepository.FindEach(ctx, spec, func(user *entity.User) error {
+		count++
+		return stop
+	})
+	assert.ErrorIs(s.T(), err, stop)
+	assert.Equal(s.T(), 1, count)
+}
+
+func (s *BasicOperationTestSuite) Test_FindInBatches() {
+	ctx := context.Background()
+
+	total, err := s.UserRepository.CountBy(ctx, nil)
+	assert.NoError(s.T(), err)
+
+	s.T().Log("Test_FindInBatches: Iterate all users 3 at a time by birthday")
+	seen := make(map[uint]bool)
+	var previous time.Time
+	batches := 0
+	err = s.UserRepository.FindInBatches(ctx, nil, 3, func(users []*entity.User) error {
+		assert.LessOrEqual(s.T(), len(users), 3)
+		for _, user := range users {
+			assert.False(s.T(), seen[user.ID], "user %d is repeated", user.ID)
+			assert.False(s.T(), user.Birthday.Before(previous))
+			seen[user.ID] = true
+			previous = user.Birthday
+		}
+		batches++
+		return nil
+	}, contract.Asc("birthday"))
+	assert.NoError(s.T(), err)
+	assert.Equal(s.T(), total, int64(len(seen)))
+	assert.Equal(s.T(), contract.TotalPage(total, 3), batches)
+
+	s.T().Log("Test_FindInBatches: Find with an invalid batch size")
+	err = s.UserRepository.FindInBatches(ctx, nil, 0, func(users []*entity.User) error { return nil })
+	assert.Error(s.T(), err)
+}
+
+func (s *BasicOperationTestSuite) Test_FindStream() {
+	ctx := context.Background()
+
+	total, err := s.UserRepository.CountBy(ctx, nil)
+	assert.NoError(s.T(), err)
+
+	s.T().Log("Test_FindStream: Receive all users")
+	users, errs := s.UserRepository.FindStream(ctx, nil, 2)
+	count := 0
+	for range users {
+		count++
+	}
+	assert.NoError(s.T(), <-errs)
+	assert.Equal(s.T(), total, int64(count))
+
+	s.T().Log("Test_FindStream: Stop receiving by cancelling the context")
+	cancelled, cancel := context.WithCancel(ctx)
+	users, errs = s.UserRepository.FindStream(cancelled, nil, 0)
+	<-users
+	cancel()
+	for range users {
+	}
+	assert.ErrorIs(s.T(), <-errs, context.Canceled)
+}
+
+func (s *BasicOperationTestSuite) Test_FindByOperator() {
+	ctx := context.Background()
+
+	s.T().Log("Test_FindByOperator: Find users with age in (21, 23)")
+	users, err := s.UserRepository.FindByOperator(ctx, entity.User{Age: mark.TargetInt}, contract.OpIn, []int{21, 23}, -1)
+	assert.NoError(s.T(), err)
+	assert.Len(s.T(), users, 4)
+	for _, user := range users {
+		assert.Contains(s.T(), []int{21, 23}, user.Age)
+	}
+
+	s.T().Log("Test_FindByOperator: Find users with age not equal to 20")
+	users, err = s.UserRepository.FindByOperator(ctx, entity.User{Age: mark.TargetInt}, contract.OpNeq, 20, -1)
+	assert.NoError(s.T(), err)
+	for _, user := range users {
+		assert.NotEqual(s.T(), 20, user.Age)
+	}
+
+	s.T().Log("Test_FindByOperator: Find users with username starts with user1")
+	users, err = s.UserRepository.FindByOperator(ctx, entity.User{Username: mark.TargetString}, contract.OpPrefix, "user1", -1)
+	assert.NoError(s.T(), err)
+	for _, user := range users {
+		assert.Contains(s.T(), []string{"user1", "user10"}, user.Username)
+	}
+
+	s.T().Log("Test_FindByOperator: Find users with mismatched value type")
+	_, err = s.UserRepository.FindByOperator(ctx, entity.User{Age: mark.TargetInt}, contract.OpGt, "20", -1)
+	assert.Error(s.T(), err)
+}
+
+func (s *BasicOperationTestSuite) Test_FindByField() {
+	ctx := context.Background()
+	age := gorme.Field(func(u *entity.User) *int { return &u.Age })
+
+	s.T().Log("Test_FindByField: Find users with age greater than 21 by a selected field")
+	users, err := s.UserRepository.FindIntGT(ctx, age, 21, -1)
+	assert.NoError(s.T(), err)
+	assert.NotEmpty(s.T(), users)
+	for _, user := range users {
+		assert.Greater(s.T(), user.Age, 21)
+	}
+
+	s.T().Log("Test_FindByField: Find users with age greater than 21 by the column name")
+	byName, err := s.UserRepository.FindIntGT(ctx, "age", 21, -1)
+	assert.NoError(s.T(), err)
+	assert.Equal(s.T(), users, byName)
+
+	s.T().Log("Test_FindByField: Find users by a field of another type")
+	_, err = s.UserRepository.FindTimeBefore(ctx, age, time.Now(), -1)
+	assert.Error(s.T(), err)
+
+	s.T().Log("Test_FindByField: Find users with age in (21, 23) sorted by age descending")
+	users, err = s.UserRepository.Find(ctx, age.In(21, 23), contract.FindOptions{Sort: []contract.Sort{age.Desc()}})
+	assert.NoError(s.T(), err)
+	assert.Len(s.T(), users, 4)
+	assert.True(s.T(), slices.IsSortedFunc(users, func(a, b *entity.User) int { return b.Age - a.Age }))
+
+	s.T().Log("Test_FindByField: Find users with age 1, which is the mark value of int")
+	users, err = s.UserRepository.Find(ctx, age.Eq(1), contract.FindOptions{})
+	assert.NoError(s.T(), err)
+	assert.Empty(s.T(), users)
+}
+
+func (s *BasicOperationTestSuite) Test_FindCompare() {
+	ctx := context.Background()
+
+	s.T().Log("Test_FindCompare: Find users with age greater than or equal to 23")
+	users, err := gorme.FindCompare[entity.User](ctx, s.UserRepository, entity.User{Age: mark.TargetInt}, contract.OpGte, 23, -1)
+	assert.NoError(s.T(), err)
+	assert.NotEmpty(s.T(), users)
+	for _, user := range users {
+		assert.GreaterOrEqual(s.T(), user.Age, 23)
+	}
+
+	s.T().Log("Test_FindCompare: Find users with birthday before 2000-05-01")
+	beforeAt := time.Date(2000, 5, 1, 0, 0, 0, 0, time.UTC)
+	users, err = gorme.FindCompare[entity.User](ctx, s.UserRepository, entity.User{Birthday: mark.TargetTime}, contract.OpLt, beforeAt, -1)
+	assert.NoError(s.T(), err)
+	for _, user := range users {
+		assert.True(s.T(), user.Birthday.Before(beforeAt))
+	}
+
+	s.T().Log("Test_FindCompare: Find users with mismatched value type")
+	_, err = gorme.FindCompare[entity.User](ctx, s.UserRepository, entity.User{Age: mark.TargetInt}, contract.OpGt, int64(20), -1)
+	assert.Error(s.T(), err)
+
+	s.T().Log("Test_FindCompare: Find users with non-comparison operator")
+	_, err = gorme.FindCompare[entity.User](ctx, s.UserRepository, entity.User{Age: mark.TargetInt}, contract.OpIn, 20, -1)
+	assert.Error(s.T(), err)
+}
+
+func (s *BasicOperationTestSuite) Test_FindAs() {
+	ctx := context.Background()
+
+	type UserSummary struct {
+		ID       uint
+		Username string
+	}
+
+	s.T().Log("Test_FindAs: Find user summaries with age 20")
+	summaries, err := gorme.FindAs[entity.User, UserSummary](ctx, s.UserRepository, contract.Eq("age", 20), contract.FindOptions{})
+	assert.NoError(s.T(), err)
+	assert.Len(s.T(), summaries, 5)
+	for _, summary := range summaries {
+		assert.NotEmpty(s.T(), summary.ID)
+		assert.NotEmpty(s.T(), summary.Username)
+	}
+
+	s.T().Log("Test_FindAs: Find as DTO without matched field")
+	_, err = gorme.FindAs[entity.User, struct{ Unknown string }](ctx, s.UserRepository, nil, contract.FindOptions{})
+	assert.Error(s.T(), err)
+}
+
 func TestRunBasicOperationTestSuite(t *testing.T) {
 	suite.Run(t, new(BasicOperationTestSuite))
 }
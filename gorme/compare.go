@@ -0,0 +1,64 @@
+package gorme
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/raaaaaaaay86/go-persistence-extension/contract"
+	"github.com/raaaaaaaay86/go-persistence-extension/gorme/macro"
+	"github.com/raaaaaaaay86/go-persistence-extension/gorme/macro/operator"
+)
+
+// FindCompare returns records which target field compares with value by the operator.
+// The target field is selected by the contract.Selector, such as the non-zero field
+// of the entity which has exactly the type V, so every numeric width, named numeric
+// types and time.Time are supported.
+//
+// - op: one of contract.OpEq, OpNeq, OpGt, OpGte, OpLt and OpLte.
+// - limit: -1 means no limit.
+//
+//	type Cents int64
+//	// Return invoices which amount is greater than 1000 cents
+//	results, err := FindCompare[Invoice](ctx, repo, Invoice{Amount: mark.Target[Cents]()}, contract.OpGt, Cents(1000), -1)
+func FindCompare[T any, V contract.Comparable](
+	ctx context.Context,
+	repo Connector,
+	entity contract.Selector,
+	op contract.Operator,
+	value V,
+	limit int,
+	sorts ...contract.Sort,
+) ([]*T, error) {
+	enum, err := compareOperator(op)
+	if err != nil {
+		return nil, err
+	}
+	return macro.CompareFind[T](ctx, repo.DB(), entity, value, enum, limit, sorts...)
+}
+
+// PFindCompare is the paginated version of FindCompare.
+func PFindCompare[T any, V contract.Comparable](
+	ctx context.Context,
+	repo Connector,
+	entity contract.Selector,
+	op contract.Operator,
+	value V,
+	page int,
+	pageSize int,
+	sorts ...contract.Sort,
+) (*contract.Pagination[T], error) {
+	enum, err := compareOperator(op)
+	if err != nil {
+		return nil, err
+	}
+	return macro.ComparePFind[T](ctx, repo.DB(), entity, value, enum, page, pageSize, sorts...)
+}
+
+func compareOperator(op contract.Operator) (operator.Enum, error) {
+	switch op {
+	case contract.OpEq, contract.OpNeq, contract.OpGt, contract.OpGte, contract.OpLt, contract.OpLte:
+		return macro.OperatorOf(op)
+	default:
+		return "", fmt.Errorf("operator (%s) is not a comparison operator", op)
+	}
+}
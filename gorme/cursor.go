@@ -0,0 +1,97 @@
+package gorme
+
+import (
+	"context"
+	"errors"
+
+	"github.com/raaaaaaaay86/go-persistence-extension/contract"
+	"github.com/raaaaaaaay86/go-persistence-extension/gorme/macro"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var _ = contract.CursorPaginated[any](&CursorRepository[any]{})
+
+// CursorConfig configures the cursors of CursorRepository.
+//
+// - Secret: the key signing the cursors, so a client can't forge or alter them. It's
+// required, and must be the same across restarts and replicas for the cursors held
+// by the clients to stay valid, e.g. loaded from the configuration of the service.
+type CursorConfig struct {
+	Secret []byte
+}
+
+type CursorRepository[T any] struct {
+	db     *gorm.DB
+	config CursorConfig
+}
+
+// NewCursorRepository returns an error if config has no Secret.
+func NewCursorRepository[T any](db *gorm.DB, config CursorConfig) (*CursorRepository[T], error) {
+	if len(config.Secret) == 0 {
+		return nil, errors.New("no cursor secret specified")
+	}
+	return &CursorRepository[T]{db, config}, nil
+}
+
+func NewEagerCursorRepository[T any](db *gorm.DB, config CursorConfig) (*CursorRepository[T], error) {
+	return NewCursorRepository[T](db.Preload(clause.Associations), config)
+}
+
+// DB implements Connector.
+func (c *CursorRepository[T]) DB() *gorm.DB {
+	return c.db
+}
+
+// CFindAll implements contract.CursorPaginated.
+//
+// CFindAll() will return the page of all records following cursor.
+//
+//	// The newest 20 users, then the 20 users after them
+//	page, err := CFindAll(ctx, "", 20, contract.Desc("created_at"))
+//	page, err = CFindAll(ctx, page.NextCursor, 20, contract.Desc("created_at"))
+func (c *CursorRepository[T]) CFindAll(
+	ctx context.Context,
+	cursor string,
+	size int,
+	sorts ...contract.Sort,
+) (*contract.CursorPage[T], error) {
+	return macro.CursorFind[T](ctx, c.db, c.config.Secret, nil, cursor, size, sorts...)
+}
+
+// CFindBy implements contract.CursorPaginated.
+//
+// CFindBy() will return the page of matched records following cursor.
+func (c *CursorRepository[T]) CFindBy(
+	ctx context.Context,
+	query contract.QueryMap,
+	cursor string,
+	size int,
+	sorts ...contract.Sort,
+) (*contract.CursorPage[T], error) {
+	expr, err := macro.BuildQueryMap(query)
+	if err != nil {
+		return nil, err
+	}
+	return macro.CursorFind[T](ctx, c.db, c.config.Secret, expr, cursor, size, sorts...)
+}
+
+// CFind implements contract.CursorPaginated.
+//
+// CFind() will return the page of records matched by spec following cursor.
+//
+//	// Users older than 20, from the oldest
+//	page, err := CFind(ctx, contract.Gt("age", 20), "", 20, contract.Desc("age"))
+func (c *CursorRepository[T]) CFind(
+	ctx context.Context,
+	spec contract.Spec,
+	cursor string,
+	size int,
+	sorts ...contract.Sort,
+) (*contract.CursorPage[T], error) {
+	expr, err := macro.BuildSpec(spec)
+	if err != nil {
+		return nil, err
+	}
+	return macro.CursorFind[T](ctx, c.db, c.config.Secret, expr, cursor, size, sorts...)
+}
@@ -0,0 +1,159 @@
+package gorme_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/raaaaaaaay86/go-persistence-extension/contract"
+	"github.com/raaaaaaaay86/go-persistence-extension/gorme"
+	"github.com/raaaaaaaay86/go-persistence-extension/gorme/entity"
+	"github.com/raaaaaaaay86/go-persistence-extension/gorme/repository"
+	"github.com/raaaaaaaay86/go-persistence-extension/gorme/util"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/gorm"
+)
+
+// cursorSecret signs the cursors of the UserRepository of the test suites.
+var cursorSecret = []byte("gpe-test-cursor-secret")
+
+type CursorOperationTestSuite struct {
+	suite.Suite
+	UserRepository *repository.UserRepository
+}
+
+func (s *CursorOperationTestSuite) SetupTest() {
+	if err := s.Setup(); err != nil {
+		s.T().Fatalf("failed to setup CursorOperationTestSuite: %s", err.Error())
+	}
+}
+
+func (s *CursorOperationTestSuite) Setup() error {
+	db, err := util.CreateGormPostgreSqlConnection(&gorm.Config{TranslateError: true})
+	if err != nil {
+		return err
+	}
+
+	s.UserRepository, err = repository.NewUserRepositoryWith(db.Debug(), repository.WithCursorSecret(cursorSecret))
+
+	return err
+}
+
+func (s *CursorOperationTestSuite) Test_CFindAll() {
+	ctx := context.Background()
+	sorts := []contract.Sort{contract.Desc("age"), contract.Asc("username")}
+
+	total, err := s.UserRepository.CountBy(ctx, nil)
+	assert.NoError(s.T(), err)
+
+	s.T().Log("Test_CFindAll: Walk forward through all users")
+	var pages []*contract.CursorPage[entity.User]
+	seen := make(map[uint]bool)
+	page, err := s.UserRepository.CFindAll(ctx, "", 3, sorts...)
+	for {
+		if !assert.NoError(s.T(), err) {
+			return
+		}
+		assert.LessOrEqual(s.T(), len(page.Results), 3)
+		assert.Equal(s.T(), len(pages) > 0, page.HasPrev())
+		for i, user := range page.Results {
+			assert.False(s.T(), seen[user.ID], "user %d is repeated", user.ID)
+			seen[user.ID] = true
+			if i > 0 {
+				assert.GreaterOrEqual(s.T(), page.Results[i-1].Age, user.Age)
+			}
+		}
+		pages = append(pages, page)
+		if !page.HasNext() {
+			break
+		}
+		page, err = s.UserRepository.CFindAll(ctx, page.NextCursor, 3, sorts...)
+	}
+	assert.Equal(s.T(), total, int64(len(seen)))
+
+	s.T().Log("Test_CFindAll: Walk backward to the first page")
+	for i := len(pages) - 1; i > 0; i-- {
+		page, err = s.UserRepository.CFindAll(ctx, pages[i].PrevCursor, 3, sorts...)
+		assert.NoError(s.T(), err)
+		assert.Equal(s.T(), pages[i-1].Results, page.Results)
+	}
+	assert.False(s.T(), page.HasPrev())
+
+	s.T().Log("Test_CFindAll: Walk backward from an empty page to the page including its boundary row")
+	var ids []uint
+	for _, user := range pages[0].Results {
+		ids = append(ids, user.ID)
+	}
+	page, err = s.UserRepository.CFind(ctx, contract.In("id", ids...), pages[0].NextCursor, 3, sorts...)
+	assert.NoError(s.T(), err)
+	assert.Empty(s.T(), page.Results)
+	assert.False(s.T(), page.HasNext())
+	if assert.True(s.T(), page.HasPrev()) {
+		page, err = s.UserRepository.CFind(ctx, contract.In("id", ids...), page.PrevCursor, 3, sorts...)
+		assert.NoError(s.T(), err)
+		assert.Equal(s.T(), pages[0].Results, page.Results)
+		assert.False(s.T(), page.HasNext())
+	}
+
+	s.T().Log("Test_CFindAll: Reject a cursor of other sorts")
+	_, err = s.UserRepository.CFindAll(ctx, pages[0].NextCursor, 3, contract.Asc("age"))
+	assert.ErrorIs(s.T(), err, contract.ErrInvalidCursor)
+
+	s.T().Log("Test_CFindAll: Reject a tampered cursor")
+	payload, signature, _ := strings.Cut(pages[0].NextCursor, ".")
+	_, err = s.UserRepository.CFindAll(ctx, payload+"x."+signature, 3, sorts...)
+	assert.ErrorIs(s.T(), err, contract.ErrInvalidCursor)
+
+	s.T().Log("Test_CFindAll: Accept a cursor of another repository with the same secret")
+	restarted, err := repository.NewUserRepositoryWith(s.UserRepository.DB(), repository.WithCursorSecret(cursorSecret))
+	assert.NoError(s.T(), err)
+	page, err = restarted.CFindAll(ctx, pages[0].NextCursor, 3, sorts...)
+	assert.NoError(s.T(), err)
+	assert.Equal(s.T(), pages[1].Results, page.Results)
+
+	s.T().Log("Test_CFindAll: Reject a cursor signed with another secret")
+	other, err := repository.NewUserRepositoryWith(s.UserRepository.DB(), repository.WithCursorSecret([]byte("other-secret")))
+	assert.NoError(s.T(), err)
+	_, err = other.CFindAll(ctx, pages[0].NextCursor, 3, sorts...)
+	assert.ErrorIs(s.T(), err, contract.ErrInvalidCursor)
+
+	s.T().Log("Test_CFindAll: Require a cursor secret")
+	_, err = gorme.NewCursorRepository[entity.User](s.UserRepository.DB(), gorme.CursorConfig{})
+	assert.Error(s.T(), err)
+}
+
+func (s *CursorOperationTestSuite) Test_CFind() {
+	ctx := context.Background()
+
+	s.T().Log("Test_CFind: Walk through users with age >= 21 ordered by birthday")
+	var users []entity.User
+	page, err := s.UserRepository.CFind(ctx, contract.Gte("age", 21), "", 2, contract.Asc("birthday"))
+	for {
+		if !assert.NoError(s.T(), err) {
+			return
+		}
+		users = append(users, page.Results...)
+		if !page.HasNext() {
+			break
+		}
+		page, err = s.UserRepository.CFind(ctx, contract.Gte("age", 21), page.NextCursor, 2, contract.Asc("birthday"))
+	}
+	count, err := s.UserRepository.CountBy(ctx, contract.QueryMap{"age": contract.Gte("age", 21)})
+	assert.NoError(s.T(), err)
+	assert.Equal(s.T(), count, int64(len(users)))
+	for i, user := range users {
+		assert.GreaterOrEqual(s.T(), user.Age, 21)
+		if i > 0 {
+			assert.False(s.T(), user.Birthday.Before(users[i-1].Birthday))
+		}
+	}
+
+	s.T().Log("Test_CFind: Find with an invalid page size")
+	_, err = s.UserRepository.CFind(ctx, contract.Gte("age", 21), "", 0)
+	assert.Error(s.T(), err)
+}
+
+func TestCursorOperationTestSuite(t *testing.T) {
+	suite.Run(t, new(CursorOperationTestSuite))
+}
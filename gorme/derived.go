@@ -0,0 +1,36 @@
+package gorme
+
+import (
+	"context"
+
+	"github.com/raaaaaaaay86/go-persistence-extension/contract"
+	"github.com/raaaaaaaay86/go-persistence-extension/gorme/macro"
+)
+
+// FindBySpec returns records of T matched by the spec. It's the same as
+// contract.Basic.Find, for the code which only has a Connector such as the
+// query implementations generated by gpe gen.
+func FindBySpec[T any](ctx context.Context, repo Connector, spec contract.Spec, opts contract.FindOptions) ([]*T, error) {
+	return macro.FindBySpec[T](ctx, repo.DB(), spec, opts)
+}
+
+// GetBySpec returns the first record of T matched by the spec in the order of
+// sorts. It returns gorm.ErrRecordNotFound if there is no matched record.
+func GetBySpec[T any](ctx context.Context, repo Connector, spec contract.Spec, sorts ...contract.Sort) (*T, error) {
+	return macro.GetBySpec[T](ctx, repo.DB(), spec, sorts...)
+}
+
+// CountBySpec counts the records of T matched by the spec.
+func CountBySpec[T any](ctx context.Context, repo Connector, spec contract.Spec) (int64, error) {
+	return macro.CountBySpec[T](ctx, repo.DB(), spec)
+}
+
+// ExistsBySpec reports whether any record of T is matched by the spec.
+func ExistsBySpec[T any](ctx context.Context, repo Connector, spec contract.Spec) (bool, error) {
+	return macro.ExistsBySpec[T](ctx, repo.DB(), spec)
+}
+
+// DeleteBySpec deletes the records of T matched by the spec and returns the affected rows.
+func DeleteBySpec[T any](ctx context.Context, repo Connector, spec contract.Spec) (int64, error) {
+	return macro.DeleteBySpec[T](ctx, repo.DB(), spec)
+}
@@ -0,0 +1,125 @@
+package gorme_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/raaaaaaaay86/go-persistence-extension/gorme"
+	"github.com/raaaaaaaay86/go-persistence-extension/gorme/repository"
+	"github.com/raaaaaaaay86/go-persistence-extension/gorme/util"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/gorm"
+)
+
+type DerivedQueryOperationTestSuite struct {
+	suite.Suite
+	UserRepository *repository.UserRepository
+	UserQueries    repository.UserQueries
+}
+
+func (s *DerivedQueryOperationTestSuite) SetupTest() {
+	if err := s.Setup(); err != nil {
+		s.T().Fatalf("failed to setup DerivedQueryOperationTestSuite: %s", err.Error())
+	}
+}
+
+func (s *DerivedQueryOperationTestSuite) Setup() error {
+	db, err := util.CreateGormPostgreSqlConnection(&gorm.Config{TranslateError: true})
+	if err != nil {
+		return err
+	}
+
+	s.UserRepository = repository.NewUserRepository(db.Debug())
+	s.UserQueries = repository.NewUserQueries(s.UserRepository)
+
+	return nil
+}
+
+func (s *DerivedQueryOperationTestSuite) Test_Find() {
+	ctx := context.Background()
+
+	s.T().Log("Test_Find: Find user4 older than 22")
+	users, err := s.UserQueries.FindByUsernameAndAgeGreaterThan(ctx, "user4", 22)
+	assert.NoError(s.T(), err)
+	assert.Len(s.T(), users, 1)
+
+	users, err = s.UserQueries.FindByUsernameAndAgeGreaterThan(ctx, "user4", 23)
+	assert.NoError(s.T(), err)
+	assert.Empty(s.T(), users)
+
+	s.T().Log("Test_Find: Find users between 21 and 23 ordered by birthday")
+	users, err = s.UserQueries.FindByAgeBetweenOrderByBirthdayDesc(ctx, 21, 23)
+	assert.NoError(s.T(), err)
+	assert.NotEmpty(s.T(), users)
+	for i, user := range users {
+		assert.GreaterOrEqual(s.T(), user.Age, 21)
+		assert.LessOrEqual(s.T(), user.Age, 23)
+		if i > 0 {
+			assert.False(s.T(), user.Birthday.After(users[i-1].Birthday))
+		}
+	}
+
+	s.T().Log("Test_Find: Find users by email domain or age")
+	users, err = s.UserQueries.FindByEmailEndingWithOrAgeIn(ctx, "@unknown.com", []int{23, 24})
+	assert.NoError(s.T(), err)
+	assert.NotEmpty(s.T(), users)
+	for _, user := range users {
+		assert.Contains(s.T(), []int{23, 24}, user.Age)
+	}
+}
+
+func (s *DerivedQueryOperationTestSuite) Test_Get() {
+	ctx := context.Background()
+
+	user, err := s.UserQueries.GetByUsername(ctx, "user1")
+	assert.NoError(s.T(), err)
+	assert.Equal(s.T(), "user1", user.Username)
+
+	_, err = s.UserQueries.GetByUsername(ctx, "nobody")
+	assert.True(s.T(), errors.Is(err, gorm.ErrRecordNotFound))
+}
+
+func (s *DerivedQueryOperationTestSuite) Test_CountAndExists() {
+	ctx := context.Background()
+
+	count, err := s.UserQueries.CountByAgeGreaterThanEqual(ctx, 23)
+	assert.NoError(s.T(), err)
+	assert.Greater(s.T(), count, int64(0))
+
+	exists, err := s.UserQueries.ExistsByEmail(ctx, "test@mail.com")
+	assert.NoError(s.T(), err)
+	assert.True(s.T(), exists)
+
+	exists, err = s.UserQueries.ExistsByEmail(ctx, "nobody@mail.com")
+	assert.NoError(s.T(), err)
+	assert.False(s.T(), exists)
+}
+
+func (s *DerivedQueryOperationTestSuite) Test_Delete() {
+	rollback := errors.New("rollback")
+
+	err := gorme.Transaction(context.Background(), s.UserRepository, func(ctx context.Context) error {
+		affected, err := s.UserQueries.DeleteByUsernameStartingWith(ctx, "")
+		assert.Error(s.T(), err)
+		assert.Zero(s.T(), affected)
+
+		affected, err = s.UserQueries.DeleteByUsernameStartingWith(ctx, "user1")
+		assert.NoError(s.T(), err)
+		assert.Equal(s.T(), int64(2), affected)
+
+		users, err := s.UserQueries.FindByAgeBetweenOrderByBirthdayDesc(ctx, 0, 100)
+		assert.NoError(s.T(), err)
+		for _, user := range users {
+			assert.False(s.T(), strings.HasPrefix(user.Username, "user1"))
+		}
+		return rollback
+	})
+	assert.ErrorIs(s.T(), err, rollback)
+}
+
+func TestDerivedQueryOperationTestSuite(t *testing.T) {
+	suite.Run(t, new(DerivedQueryOperationTestSuite))
+}
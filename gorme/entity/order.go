@@ -0,0 +1,18 @@
+package entity
+
+import (
+	"gorm.io/gorm"
+)
+
+type Order struct {
+	gorm.Model
+	UserID uint
+	Amount float64
+	Status string
+	User   *User
+}
+
+type Team struct {
+	gorm.Model
+	Name string
+}
@@ -0,0 +1,34 @@
+package entity
+
+import (
+	"github.com/raaaaaaaay86/go-persistence-extension/contract"
+	"github.com/raaaaaaaay86/go-persistence-extension/gorme"
+	"gorm.io/gorm"
+)
+
+type Profile struct {
+	gorm.Model
+	UserID   uint
+	Settings gorme.JSON[ProfileSettings]
+}
+
+type ProfileSettings struct {
+	Theme        string                `json:"theme"`
+	Level        int                   `json:"level"`
+	Tags         []string              `json:"tags,omitempty"`
+	Notification *NotificationSettings `json:"notification,omitempty"`
+}
+
+type NotificationSettings struct {
+	Email bool `json:"email"`
+}
+
+type ProfileFilter struct {
+	Theme    *string `gpe:"column=settings,path=theme"`
+	MinLevel *int    `gpe:"column=settings,path=level,op=gte"`
+	Tag      *string `gpe:"column=settings,path=tags,op=json_contains"`
+}
+
+func (p ProfileFilter) ToMap() (contract.QueryMap, error) {
+	return gorme.ParseFilter(p)
+}
@@ -14,6 +14,11 @@ type User struct {
 	Email    string
 	Age      int
 	Birthday time.Time
+	Roles    gorme.Array[string]
+	Nickname *string
+	Profile  *Profile
+	Orders   []Order
+	Teams    []Team `gorm:"many2many:user_teams"`
 }
 
 type UserQueryMapper struct {
@@ -24,8 +29,23 @@ type UserQueryMapper struct {
 	Email     *string
 	Age       *int
 	Birthday  *time.Time
+	Roles     *gorme.Array[string]
 }
 
 func (u UserQueryMapper) ToMap() contract.QueryMap {
 	return gorme.ToQueryMap(u)
-}
\ No newline at end of file
+}
+
+type UserFilter struct {
+	Username *string    `gpe:"op=like,match=prefix"`
+	Emails   []string   `gpe:"column=email,op=in"`
+	MinAge   *int       `gpe:"column=age,op=gte"`
+	MaxAge   *int       `gpe:"column=age,op=lte"`
+	BornFrom *time.Time `gpe:"column=birthday,op=gte"`
+	Role     *string    `gpe:"column=roles,op=array_any"`
+	AnyRoles []string   `gpe:"column=roles,op=array_overlap"`
+}
+
+func (u UserFilter) ToMap() (contract.QueryMap, error) {
+	return gorme.ParseFilter(u)
+}
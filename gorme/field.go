@@ -0,0 +1,142 @@
+package gorme
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/raaaaaaaay86/go-persistence-extension/contract"
+	"github.com/raaaaaaaay86/go-persistence-extension/gorme/util"
+)
+
+// Column is a field of the entity T with type V selected by Field. It builds
+// the Specs, sorts and aggregates of the column with values checked at compile
+// time, and it's a contract.ColumnSelector accepted by the methods taking a
+// contract.Selector, such as FindIntGT, FindNull or Sum.
+type Column[T any, V any] struct {
+	column string
+}
+
+// Field returns the Column of the field returned by selector. The field is
+// resolved by its address, so it doesn't depend on the field value as the mark
+// values do. Like util.FlattenStruct, the fields of embedded and nested structs
+// are selectable, e.g. &u.Model.ID or &u.ID. When T is a pointer type, the
+// selector receives an allocated entity. It panics if selector doesn't return
+// a column field of T.
+//
+//	age := gorme.Field(func(u *User) *int { return &u.Age })
+//	results, err := repo.Find(ctx, age.Gt(22), contract.FindOptions{Sort: []contract.Sort{age.Desc()}})
+//	results, err := repo.FindIntGT(ctx, age, 22, -1)
+func Field[T any, V any](selector func(*T) *V) Column[T, V] {
+	var entity T
+	v := structValue(&entity)
+	if v.Kind() != reflect.Struct {
+		panic(fmt.Sprintf("gorme: field selector of non-struct type %T", entity))
+	}
+
+	target := selector(&entity)
+	if target == nil {
+		panic(fmt.Sprintf("gorme: field selector of %T returns nil", entity))
+	}
+	addr := reflect.ValueOf(target).Pointer()
+	want := reflect.TypeOf((*V)(nil)).Elem()
+
+	fields, values := util.FlattenStruct(v)
+	for i, field := range fields {
+		value := values[i]
+		if value.Addr().Pointer() != addr || value.Type() != want {
+			continue
+		}
+		return Column[T, V]{column: util.ColumnName(field)}
+	}
+
+	panic(fmt.Sprintf("gorme: field selector of %T doesn't return a column field of type %s", entity, want))
+}
+
+// ColumnName implements contract.ColumnSelector.
+func (c Column[T, V]) ColumnName() string {
+	return c.column
+}
+
+// ColumnType implements contract.ColumnSelector.
+func (c Column[T, V]) ColumnType() reflect.Type {
+	return reflect.TypeOf((*V)(nil)).Elem()
+}
+
+// structValue returns the struct of entity, allocating it when T is a pointer
+// type such as *User.
+func structValue[T any](entity *T) reflect.Value {
+	v := reflect.ValueOf(entity).Elem()
+	if v.Kind() == reflect.Ptr && v.Type().Elem().Kind() == reflect.Struct {
+		v.Set(reflect.New(v.Type().Elem()))
+		v = v.Elem()
+	}
+	return v
+}
+
+func (c Column[T, V]) Eq(value V) contract.Spec {
+	return contract.Eq(c.column, value)
+}
+
+func (c Column[T, V]) Neq(value V) contract.Spec {
+	return contract.Neq(c.column, value)
+}
+
+func (c Column[T, V]) Gt(value V) contract.Spec {
+	return contract.Gt(c.column, value)
+}
+
+func (c Column[T, V]) Gte(value V) contract.Spec {
+	return contract.Gte(c.column, value)
+}
+
+func (c Column[T, V]) Lt(value V) contract.Spec {
+	return contract.Lt(c.column, value)
+}
+
+func (c Column[T, V]) Lte(value V) contract.Spec {
+	return contract.Lte(c.column, value)
+}
+
+func (c Column[T, V]) In(values ...V) contract.Spec {
+	return contract.In(c.column, values...)
+}
+
+func (c Column[T, V]) NotIn(values ...V) contract.Spec {
+	return contract.NotIn(c.column, values...)
+}
+
+func (c Column[T, V]) Between(start V, end V) contract.Spec {
+	return contract.Between(c.column, start, end)
+}
+
+func (c Column[T, V]) IsNull() contract.Spec {
+	return contract.IsNull(c.column)
+}
+
+func (c Column[T, V]) IsNotNull() contract.Spec {
+	return contract.IsNotNull(c.column)
+}
+
+func (c Column[T, V]) Asc() contract.Sort {
+	return contract.Asc(c.column)
+}
+
+func (c Column[T, V]) Desc() contract.Sort {
+	return contract.Desc(c.column)
+}
+
+func (c Column[T, V]) Sum() contract.Aggregate {
+	return contract.SumOf(c.column)
+}
+
+func (c Column[T, V]) Avg() contract.Aggregate {
+	return contract.AvgOf(c.column)
+}
+
+func (c Column[T, V]) Min() contract.Aggregate {
+	return contract.MinOf(c.column)
+}
+
+func (c Column[T, V]) Max() contract.Aggregate {
+	return contract.MaxOf(c.column)
+}
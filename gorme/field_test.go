@@ -0,0 +1,76 @@
+package gorme_test
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/raaaaaaaay86/go-persistence-extension/contract"
+	"github.com/raaaaaaaay86/go-persistence-extension/gorme"
+	"github.com/raaaaaaaay86/go-persistence-extension/gorme/entity"
+	"github.com/raaaaaaaay86/go-persistence-extension/gorme/util"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+type fieldProbe struct {
+	gorm.Model
+	Score   int
+	Bonus   int
+	Code    string `gorm:"column:product_code"`
+	Deleted gorm.DeletedAt
+	Note    *string
+}
+
+func TestField(t *testing.T) {
+	t.Log("TestField: resolve columns of fields, embedded fields and tagged fields")
+	assert.Equal(t, "age", gorme.Field(func(u *entity.User) *int { return &u.Age }).ColumnName())
+	assert.Equal(t, "id", gorme.Field(func(u *entity.User) *uint { return &u.ID }).ColumnName())
+	assert.Equal(t, "created_at", gorme.Field(func(u *entity.User) *time.Time { return &u.Model.CreatedAt }).ColumnName())
+	assert.Equal(t, "product_code", gorme.Field(func(p *fieldProbe) *string { return &p.Code }).ColumnName())
+
+	t.Log("TestField: select one of several fields of the same type without ambiguity")
+	bonus := gorme.Field(func(p *fieldProbe) *int { return &p.Bonus })
+	_, err := util.ParseMarkedField(fieldProbe{Score: 1, Bonus: 1})
+	assert.Error(t, err)
+	field, err := util.ParseTargetField(bonus, reflect.TypeOf(0))
+	assert.NoError(t, err)
+	assert.Equal(t, "bonus", field.ColumnName)
+
+	t.Log("TestField: select the fields which zero value is not a mark value")
+	deleted := gorme.Field(func(p *fieldProbe) *gorm.DeletedAt { return &p.Deleted })
+	field, err = util.ParseMarkedField(deleted)
+	assert.NoError(t, err)
+	assert.Equal(t, "deleted", field.ColumnName)
+	assert.Equal(t, reflect.TypeOf(gorm.DeletedAt{}), field.ReflectType)
+
+	t.Log("TestField: reject a column of another type than the target")
+	_, err = util.ParseTargetField(bonus, reflect.TypeOf(time.Time{}))
+	assert.Error(t, err)
+
+	t.Log("TestField: select a column by its name")
+	field, err = util.ParseTargetField("age", reflect.TypeOf(0))
+	assert.NoError(t, err)
+	assert.Equal(t, "age", field.ColumnName)
+	_, err = util.ParseMarkedField("age; DROP TABLE users")
+	assert.Error(t, err)
+
+	t.Log("TestField: build specs, sorts and aggregates of the column")
+	age := gorme.Field(func(u *entity.User) *int { return &u.Age })
+	assert.Equal(t, contract.Gt("age", 22), age.Gt(22))
+	assert.Equal(t, contract.In("age", 21, 23), age.In(21, 23))
+	assert.Equal(t, contract.Desc("age"), age.Desc())
+	assert.Equal(t, contract.SumOf("age"), age.Sum())
+
+	t.Log("TestField: select the field of a pointer entity")
+	username := gorme.Field(func(u **entity.User) *string { return &(*u).Username })
+	assert.Equal(t, "username", username.ColumnName())
+
+	t.Log("TestField: panic on a selector which doesn't return a field")
+	assert.Panics(t, func() {
+		gorme.Field(func(u *entity.User) *int { return new(int) })
+	})
+	assert.Panics(t, func() {
+		gorme.Field(func(u *entity.User) *int { return nil })
+	})
+}
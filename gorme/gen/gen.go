@@ -0,0 +1,432 @@
+// Package gen generates the implementations of derived query interfaces. A method
+// of the interface is named after its query, e.g. FindByUsernameAndAgeGreaterThan,
+// see ParseMethodName for the grammar. It's used by the gpe gen command:
+//
+//	//go:generate go run github.com/raaaaaaaay86/go-persistence-extension/cmd/gpe gen -interface UserQueries
+//	type UserQueries interface {
+//		FindByUsernameAndAgeGreaterThan(ctx context.Context, username string, age int) ([]*entity.User, error)
+//	}
+package gen
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/importer"
+	"go/parser"
+	"go/printer"
+	"go/token"
+	"go/types"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"gorm.io/gorm/schema"
+)
+
+const (
+	contractPath = "github.com/raaaaaaaay86/go-persistence-extension/contract"
+	gormePath    = "github.com/raaaaaaaay86/go-persistence-extension/gorme"
+)
+
+// Config describes the interface to implement.
+//
+// - Dir: the directory of the package declaring the interface.
+// - Interface: the name of the interface.
+// - Output: the name of the generated file in Dir. It's excluded from the type checking,
+// so the stale generated code never fails the generation.
+// - Entity: the entity type such as entity.User. Default is the entity returned by the
+// Find and Get methods, the methods of the interface must all refer the same entity.
+// - NamingStrategy: the naming strategy of the gorm.Config of the database, which names
+// the columns without a column tag. Default is schema.NamingStrategy{}.
+type Config struct {
+	Dir            string
+	Interface      string
+	Output         string
+	Entity         string
+	NamingStrategy schema.Namer
+}
+
+// Generator type checks packages to generate the implementations. The imported
+// packages are cached, so a Generator is reused to generate several interfaces.
+type Generator struct {
+	fset     *token.FileSet
+	importer types.ImporterFrom
+}
+
+func NewGenerator() *Generator {
+	fset := token.NewFileSet()
+	return &Generator{
+		fset:     fset,
+		importer: importer.ForCompiler(fset, "source", nil).(types.ImporterFrom),
+	}
+}
+
+// Generate returns the formatted source of the implementation described by config.
+func (g *Generator) Generate(config Config) ([]byte, error) {
+	dir, err := filepath.Abs(config.Dir)
+	if err != nil {
+		return nil, err
+	}
+
+	files, err := g.parseDir(dir, config.Output)
+	if err != nil {
+		return nil, err
+	}
+	if len(files) == 0 {
+		return nil, fmt.Errorf("no go file in (%s)", dir)
+	}
+
+	info := &types.Info{
+		Types:     make(map[ast.Expr]types.TypeAndValue),
+		Defs:      make(map[*ast.Ident]types.Object),
+		Uses:      make(map[*ast.Ident]types.Object),
+		Implicits: make(map[ast.Node]types.Object),
+	}
+	checker := types.Config{
+		Importer: g.importer,
+		// The package may refer the generated code which doesn't exist yet.
+		Error: func(error) {},
+	}
+	pkg, _ := checker.Check(files[0].Name.Name, g.fset, files, info)
+
+	file, spec := findInterface(files, config.Interface)
+	if spec == nil {
+		return nil, fmt.Errorf("interface (%s) not found in (%s)", config.Interface, dir)
+	}
+
+	w := &writer{
+		config:  config,
+		pkg:     pkg,
+		info:    info,
+		fset:    g.fset,
+		imports: make(map[string]string),
+	}
+	if err := w.collectImports(file); err != nil {
+		return nil, err
+	}
+	if err := w.resolveEntity(spec.Type.(*ast.InterfaceType)); err != nil {
+		return nil, err
+	}
+	if err := w.writeInterface(spec.Type.(*ast.InterfaceType)); err != nil {
+		return nil, err
+	}
+
+	source, err := format.Source(w.source())
+	if err != nil {
+		return nil, fmt.Errorf("format generated code: %w", err)
+	}
+	return source, nil
+}
+
+func (g *Generator) parseDir(dir string, output string) ([]*ast.File, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var files []*ast.File
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasSuffix(name, ".go") || strings.HasSuffix(name, "_test.go") {
+			continue
+		}
+		if output != "" && name == filepath.Base(output) {
+			continue
+		}
+		file, err := parser.ParseFile(g.fset, filepath.Join(dir, name), nil, parser.ParseComments)
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, file)
+	}
+	return files, nil
+}
+
+func findInterface(files []*ast.File, name string) (*ast.File, *ast.TypeSpec) {
+	for _, file := range files {
+		for _, decl := range file.Decls {
+			gen, ok := decl.(*ast.GenDecl)
+			if !ok || gen.Tok != token.TYPE {
+				continue
+			}
+			for _, s := range gen.Specs {
+				spec := s.(*ast.TypeSpec)
+				if _, ok := spec.Type.(*ast.InterfaceType); ok && spec.Name.Name == name {
+					return file, spec
+				}
+			}
+		}
+	}
+	return nil, nil
+}
+
+// property is a column field of the entity as flattened by flattenStruct.
+type property struct {
+	Name   string
+	Column string
+	Type   types.Type
+}
+
+type writer struct {
+	config Config
+	pkg    *types.Package
+	info   *types.Info
+	fset   *token.FileSet
+
+	// imports maps the package names of the interface file to their paths.
+	imports map[string]string
+	used    map[string]bool
+
+	entity     types.Type
+	entityExpr string
+	properties map[string]property
+
+	body bytes.Buffer
+}
+
+func (w *writer) collectImports(file *ast.File) error {
+	w.used = map[string]bool{"context": true, "contract": true, "gorme": true}
+	w.imports["context"] = "context"
+	w.imports["contract"] = contractPath
+	w.imports["gorme"] = gormePath
+
+	for _, spec := range file.Imports {
+		path, err := strconv.Unquote(spec.Path.Value)
+		if err != nil {
+			return err
+		}
+		name := ""
+		if spec.Name != nil {
+			name = spec.Name.Name
+		} else if obj, ok := w.info.Implicits[spec].(*types.PkgName); ok {
+			name = obj.Imported().Name()
+		} else {
+			name = filepath.Base(path)
+		}
+		if name == "_" || name == "." {
+			continue
+		}
+		if existing, ok := w.imports[name]; ok && existing != path {
+			return fmt.Errorf("import (%s) of the interface file conflicts with (%s)", path, existing)
+		}
+		w.imports[name] = path
+	}
+	return nil
+}
+
+// resolveEntity finds the entity type and its properties.
+func (w *writer) resolveEntity(iface *ast.InterfaceType) error {
+	if w.config.Entity != "" {
+		expr, err := parser.ParseExpr(w.config.Entity)
+		if err != nil {
+			return fmt.Errorf("invalid entity (%s): %w", w.config.Entity, err)
+		}
+		tv, err := types.Eval(w.fset, w.pkg, iface.Pos(), w.config.Entity)
+		if err != nil || !tv.IsType() {
+			return fmt.Errorf("entity (%s) is not a type", w.config.Entity)
+		}
+		w.entity = tv.Type
+		w.entityExpr = w.config.Entity
+		w.useExpr(expr)
+	} else {
+		for _, field := range iface.Methods.List {
+			fn, ok := field.Type.(*ast.FuncType)
+			if !ok || fn.Results == nil || len(fn.Results.List) == 0 {
+				continue
+			}
+			name := field.Names[0].Name
+			if !strings.HasPrefix(name, string(ActionFind)) && !strings.HasPrefix(name, string(ActionGet)) {
+				continue
+			}
+			expr := fn.Results.List[0].Type
+			if slice, ok := expr.(*ast.ArrayType); ok && slice.Len == nil {
+				expr = slice.Elt
+			}
+			star, ok := expr.(*ast.StarExpr)
+			if !ok {
+				continue
+			}
+			w.entity = w.info.Types[star.X].Type
+			w.entityExpr = w.print(star.X)
+			w.useExpr(star.X)
+			break
+		}
+		if w.entity == nil {
+			return fmt.Errorf("interface (%s) has no Find or Get method, the entity must be given", w.config.Interface)
+		}
+	}
+
+	st, ok := w.entity.Underlying().(*types.Struct)
+	if !ok {
+		return fmt.Errorf("entity (%s) is not a struct", w.entityExpr)
+	}
+	namer := w.config.NamingStrategy
+	if namer == nil {
+		namer = schema.NamingStrategy{}
+	}
+	table := ""
+	if named, ok := w.entity.(*types.Named); ok {
+		table = namer.TableName(named.Obj().Name())
+	}
+	w.properties = make(map[string]property)
+	flattenStruct(st, namer, table, "", w.properties)
+	return nil
+}
+
+// flattenStruct collects the column fields of st the way gorm parses a schema.
+// The embedded structs, either anonymous or tagged embedded, are flattened with
+// their embeddedPrefix. The associations, which are the fields of a non-column
+// struct, a pointer to it or a slice of it, and the fields tagged "-" are not
+// columns.
+func flattenStruct(st *types.Struct, namer schema.Namer, table string, prefix string, properties map[string]property) {
+	for i := 0; i < st.NumFields(); i++ {
+		field := st.Field(i)
+		if !field.Exported() {
+			continue
+		}
+		tag := schema.ParseTagSetting(reflect.StructTag(st.Tag(i)).Get("gorm"), ";")
+		if ignored, ok := tag["-"]; ok && (ignored == "-" || strings.EqualFold(ignored, "all")) {
+			continue
+		}
+
+		typ := field.Type()
+		if ptr, ok := typ.Underlying().(*types.Pointer); ok {
+			typ = ptr.Elem()
+		}
+		if embedded, ok := typ.Underlying().(*types.Struct); ok && !isColumnType(typ) {
+			if _, ok := tag["EMBEDDED"]; ok || field.Anonymous() {
+				flattenStruct(embedded, namer, table, prefix+tag["EMBEDDEDPREFIX"], properties)
+			}
+			continue
+		}
+		if isAssociation(typ) {
+			continue
+		}
+
+		// As gorm, the first field of a name is the property of the name.
+		if _, ok := properties[field.Name()]; ok {
+			continue
+		}
+		column := tag["COLUMN"]
+		if column == "" {
+			column = namer.ColumnName(table, field.Name())
+		}
+		properties[field.Name()] = property{Name: field.Name(), Column: prefix + column, Type: field.Type()}
+	}
+}
+
+// isAssociation reports whether t is a slice or an array of a non-column struct
+// or of a pointer to it.
+func isAssociation(t types.Type) bool {
+	var elem types.Type
+	switch u := t.Underlying().(type) {
+	case *types.Slice:
+		elem = u.Elem()
+	case *types.Array:
+		elem = u.Elem()
+	default:
+		return false
+	}
+	if isColumnType(t) {
+		return false
+	}
+	if ptr, ok := elem.Underlying().(*types.Pointer); ok {
+		elem = ptr.Elem()
+	}
+	_, ok := elem.Underlying().(*types.Struct)
+	return ok && !isColumnType(elem)
+}
+
+// isColumnType reports whether a struct type is mapped to a single column: time.Time,
+// gorm.DeletedAt or a driver.Valuer or sql.Scanner implementation.
+func isColumnType(t types.Type) bool {
+	if named, ok := t.(*types.Named); ok {
+		switch named.Obj().Name() {
+		case "Time", "DeletedAt":
+			return true
+		}
+	}
+	for _, lookup := range []struct {
+		t      types.Type
+		method string
+	}{{t, "Value"}, {types.NewPointer(t), "Scan"}} {
+		if obj, _, _ := types.LookupFieldOrMethod(lookup.t, false, nil, lookup.method); obj != nil {
+			if _, ok := obj.(*types.Func); ok {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (w *writer) propertyNames() []string {
+	names := make([]string, 0, len(w.properties))
+	for name := range w.properties {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func (w *writer) print(expr ast.Expr) string {
+	var buf bytes.Buffer
+	_ = printer.Fprint(&buf, w.fset, expr)
+	return buf.String()
+}
+
+// useExpr marks the packages referred by expr as imported.
+func (w *writer) useExpr(expr ast.Node) {
+	ast.Inspect(expr, func(node ast.Node) bool {
+		if sel, ok := node.(*ast.SelectorExpr); ok {
+			if ident, ok := sel.X.(*ast.Ident); ok {
+				if _, ok := w.imports[ident.Name]; ok {
+					w.used[ident.Name] = true
+				}
+			}
+		}
+		return true
+	})
+}
+
+func (w *writer) source() []byte {
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "// Code generated by gpe gen. DO NOT EDIT.\n\n")
+	fmt.Fprintf(&buf, "package %s\n\n", w.pkg.Name())
+
+	names := make([]string, 0, len(w.used))
+	for name := range w.used {
+		names = append(names, name)
+	}
+	sort.Slice(names, func(i, j int) bool {
+		pi, pj := w.imports[names[i]], w.imports[names[j]]
+		if isStdlib(pi) != isStdlib(pj) {
+			return isStdlib(pi)
+		}
+		return pi < pj
+	})
+	buf.WriteString("import (\n")
+	for i, name := range names {
+		path := w.imports[name]
+		if i > 0 && isStdlib(w.imports[names[i-1]]) && !isStdlib(path) {
+			buf.WriteString("\n")
+		}
+		if filepath.Base(path) == name {
+			fmt.Fprintf(&buf, "\t%q\n", path)
+		} else {
+			fmt.Fprintf(&buf, "\t%s %q\n", name, path)
+		}
+	}
+	buf.WriteString(")\n\n")
+
+	buf.Write(w.body.Bytes())
+	return buf.Bytes()
+}
+
+func isStdlib(path string) bool {
+	return !strings.Contains(strings.Split(path, "/")[0], ".")
+}
@@ -0,0 +1,100 @@
+package gen_test
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/raaaaaaaay86/go-persistence-extension/contract"
+	"github.com/raaaaaaaay86/go-persistence-extension/gorme/gen"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm/schema"
+)
+
+var userProperties = []string{"ID", "CreatedAt", "UpdatedAt", "DeletedAt", "Username", "Email", "Age", "Birthday", "Roles"}
+
+func TestParseMethodName(t *testing.T) {
+	method, err := gen.ParseMethodName("FindByUsernameAndAgeGreaterThanEqualOrEmailEndingWithOrderByBirthdayDescAge", userProperties)
+	assert.NoError(t, err)
+	assert.Equal(t, gen.ActionFind, method.Action)
+	assert.Len(t, method.Criteria, 2)
+	assert.Len(t, method.Criteria[0], 2)
+	assert.Equal(t, "Username", method.Criteria[0][0].Property)
+	assert.Equal(t, contract.OpEq, method.Criteria[0][0].Keyword.Operator)
+	assert.Equal(t, "Age", method.Criteria[0][1].Property)
+	assert.Equal(t, contract.OpGte, method.Criteria[0][1].Keyword.Operator)
+	assert.Equal(t, contract.OpSuffix, method.Criteria[1][0].Keyword.Operator)
+	assert.Equal(t, []gen.Order{{Property: "Birthday", Desc: true}, {Property: "Age"}}, method.Orders)
+	assert.Equal(t, 3, method.Args())
+
+	method, err = gen.ParseMethodName("CountByDeletedAtIsNullAndAgeBetween", userProperties)
+	assert.NoError(t, err)
+	assert.Equal(t, gen.ActionCount, method.Action)
+	assert.Equal(t, contract.OpIsNull, method.Criteria[0][0].Keyword.Operator)
+	assert.Equal(t, 2, method.Args())
+
+	method, err = gen.ParseMethodName("FindAllOrderByCreatedAtDesc", userProperties)
+	assert.NoError(t, err)
+	assert.Empty(t, method.Criteria)
+	assert.Equal(t, []gen.Order{{Property: "CreatedAt", Desc: true}}, method.Orders)
+
+	_, err = gen.ParseMethodName("FindByUsernmaeAndAge", userProperties)
+	assert.ErrorContains(t, err, "(UsernmaeAndAge)")
+
+	_, err = gen.ParseMethodName("SearchByUsername", userProperties)
+	assert.Error(t, err)
+}
+
+func TestGenerate(t *testing.T) {
+	generator := gen.NewGenerator()
+
+	// The generated UserQueries of the repository package must be up to date.
+	source, err := generator.Generate(gen.Config{
+		Dir:       "../repository",
+		Interface: "UserQueries",
+		Output:    "user_queries_gen.go",
+	})
+	assert.NoError(t, err)
+	generated, err := os.ReadFile("../repository/user_queries_gen.go")
+	assert.NoError(t, err)
+	assert.Equal(t, string(generated), string(source))
+
+	_, err = generator.Generate(gen.Config{Dir: "testdata/typo", Interface: "MisspelledQueries"})
+	assert.ErrorContains(t, err, "no property matches (UsernmaeAndAge)")
+
+	_, err = generator.Generate(gen.Config{Dir: "testdata/typo", Interface: "MistypedQueries"})
+	assert.ErrorContains(t, err, "parameter (age) of AgeGreaterThan is string, expected int")
+
+	_, err = generator.Generate(gen.Config{Dir: "testdata/typo", Interface: "MiscountedQueries", Entity: "entity.User"})
+	assert.ErrorContains(t, err, "expects 2 parameters")
+
+	_, err = generator.Generate(gen.Config{Dir: "testdata/typo", Interface: "UnknownQueries"})
+	assert.Error(t, err)
+}
+
+func TestGenerateEmbedded(t *testing.T) {
+	generator := gen.NewGenerator()
+
+	source, err := generator.Generate(gen.Config{Dir: "testdata/embedded", Interface: "CustomerQueries"})
+	assert.NoError(t, err)
+	assert.Contains(t, string(source), `contract.Eq("home_city", city)`)
+	assert.Contains(t, string(source), `contract.Eq("home_street_name", street)`)
+	// Only the Delete method guards against an empty pattern.
+	assert.Equal(t, 1, strings.Count(string(source), `if name == "" {`))
+	assert.Equal(t, 1, strings.Count(string(source), `if city == "" {`))
+
+	source, err = generator.Generate(gen.Config{
+		Dir:            "testdata/embedded",
+		Interface:      "CustomerQueries",
+		NamingStrategy: schema.NamingStrategy{NoLowerCase: true},
+	})
+	assert.NoError(t, err)
+	assert.Contains(t, string(source), `contract.Eq("home_City", city)`)
+	assert.Contains(t, string(source), `contract.Eq("home_street_name", street)`)
+
+	_, err = generator.Generate(gen.Config{Dir: "testdata/embedded", Interface: "AssociationQueries"})
+	assert.ErrorContains(t, err, "no property matches (Manager)")
+
+	_, err = generator.Generate(gen.Config{Dir: "testdata/embedded", Interface: "IgnoredQueries"})
+	assert.ErrorContains(t, err, "no property matches (Internal)")
+}
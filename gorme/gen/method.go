@@ -0,0 +1,252 @@
+package gen
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/raaaaaaaay86/go-persistence-extension/contract"
+)
+
+// Action is the kind of a derived query method, given by the prefix of its name.
+type Action string
+
+const (
+	ActionFind   Action = "Find"
+	ActionGet    Action = "Get"
+	ActionCount  Action = "Count"
+	ActionExists Action = "Exists"
+	ActionDelete Action = "Delete"
+)
+
+// Keyword is the operator suffix of a property in a method name.
+//
+// - Operator: the contract.Operator of the generated Spec.
+// - Args: the number of method parameters consumed by the keyword.
+// - Value: the literal compared with the property when Args is 0 and Operator is contract.OpEq.
+type Keyword struct {
+	Name     string
+	Operator contract.Operator
+	Args     int
+	Value    string
+}
+
+var keywords = []Keyword{
+	{Name: "", Operator: contract.OpEq, Args: 1},
+	{Name: "Is", Operator: contract.OpEq, Args: 1},
+	{Name: "Equals", Operator: contract.OpEq, Args: 1},
+	{Name: "Not", Operator: contract.OpNeq, Args: 1},
+	{Name: "IsNot", Operator: contract.OpNeq, Args: 1},
+	{Name: "GreaterThan", Operator: contract.OpGt, Args: 1},
+	{Name: "After", Operator: contract.OpGt, Args: 1},
+	{Name: "GreaterThanEqual", Operator: contract.OpGte, Args: 1},
+	{Name: "LessThan", Operator: contract.OpLt, Args: 1},
+	{Name: "Before", Operator: contract.OpLt, Args: 1},
+	{Name: "LessThanEqual", Operator: contract.OpLte, Args: 1},
+	{Name: "Between", Operator: contract.OpBetween, Args: 2},
+	{Name: "In", Operator: contract.OpIn, Args: 1},
+	{Name: "IsIn", Operator: contract.OpIn, Args: 1},
+	{Name: "NotIn", Operator: contract.OpNotIn, Args: 1},
+	{Name: "IsNotIn", Operator: contract.OpNotIn, Args: 1},
+	{Name: "Like", Operator: contract.OpLike, Args: 1},
+	{Name: "StartingWith", Operator: contract.OpPrefix, Args: 1},
+	{Name: "StartsWith", Operator: contract.OpPrefix, Args: 1},
+	{Name: "EndingWith", Operator: contract.OpSuffix, Args: 1},
+	{Name: "EndsWith", Operator: contract.OpSuffix, Args: 1},
+	{Name: "Containing", Operator: contract.OpContains, Args: 1},
+	{Name: "Contains", Operator: contract.OpContains, Args: 1},
+	{Name: "IsNull", Operator: contract.OpIsNull},
+	{Name: "Null", Operator: contract.OpIsNull},
+	{Name: "IsNotNull", Operator: contract.OpIsNotNull},
+	{Name: "NotNull", Operator: contract.OpIsNotNull},
+	{Name: "True", Operator: contract.OpEq, Value: "true"},
+	{Name: "IsTrue", Operator: contract.OpEq, Value: "true"},
+	{Name: "False", Operator: contract.OpEq, Value: "false"},
+	{Name: "IsFalse", Operator: contract.OpEq, Value: "false"},
+}
+
+func init() {
+	// The longest keyword is tried first, so GreaterThanEqual is not read as GreaterThan.
+	sort.SliceStable(keywords, func(i, j int) bool {
+		return len(keywords[i].Name) > len(keywords[j].Name)
+	})
+}
+
+// Criterion compares a property of the entity.
+type Criterion struct {
+	Property string
+	Keyword  Keyword
+}
+
+// Order sorts the results by a property of the entity.
+type Order struct {
+	Property string
+	Desc     bool
+}
+
+// Method is a parsed derived query method name.
+//
+// - Criteria: the criteria combined by Or, each of them is combined by And.
+// - Orders: the sorts given after OrderBy.
+type Method struct {
+	Name     string
+	Action   Action
+	Criteria [][]Criterion
+	Orders   []Order
+}
+
+// Args returns the number of method parameters consumed by the criteria.
+func (m *Method) Args() int {
+	n := 0
+	for _, and := range m.Criteria {
+		for _, criterion := range and {
+			n += criterion.Keyword.Args
+		}
+	}
+	return n
+}
+
+// ParseMethodName parses a derived query method name in the style of Spring Data.
+// properties are the property names of the entity, a name which refers any other
+// property is rejected.
+//
+//	FindByUsernameAndAgeGreaterThan          username = ? AND age > ?
+//	FindByAgeBetweenOrEmailEndingWith        (age BETWEEN ? AND ?) OR email LIKE '%?'
+//	GetByUsername                            the first record with username = ?
+//	CountByAgeIn, ExistsByEmail, DeleteByAgeLessThan
+//	FindAllOrderByBirthdayDesc               every record ordered by birthday DESC
+//	FindByAgeOrderByBirthdayDescUsernameAsc  age = ? ORDER BY birthday DESC, username
+func ParseMethodName(name string, properties []string) (*Method, error) {
+	method := &Method{Name: name}
+
+	rest := ""
+	for _, action := range []Action{ActionFind, ActionGet, ActionCount, ActionExists, ActionDelete} {
+		if after, ok := strings.CutPrefix(name, string(action)+"By"); ok {
+			method.Action = action
+			rest = after
+			break
+		}
+		if action == ActionFind && (name == "FindAll" || strings.HasPrefix(name, "FindAllOrderBy")) {
+			method.Action = action
+			rest = strings.TrimPrefix(name, "FindAll")
+			break
+		}
+	}
+	if method.Action == "" {
+		return nil, fmt.Errorf("method (%s) must start with FindBy, GetBy, CountBy, ExistsBy, DeleteBy or FindAll", name)
+	}
+
+	sorted := append([]string(nil), properties...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return len(sorted[i]) > len(sorted[j])
+	})
+	p := &methodParser{properties: sorted, unknown: rest}
+
+	if method.Action == ActionFind && !strings.HasPrefix(name, "FindBy") {
+		if rest == "" {
+			return method, nil
+		}
+		orders, ok := p.orders(strings.TrimPrefix(rest, "OrderBy"))
+		if !ok {
+			return nil, p.err(name)
+		}
+		method.Orders = orders
+		return method, nil
+	}
+
+	criteria, orders, ok := p.criteria(rest)
+	if !ok {
+		return nil, p.err(name)
+	}
+	method.Criteria = criteria
+	method.Orders = orders
+
+	return method, nil
+}
+
+type methodParser struct {
+	properties []string
+	// unknown is the shortest rest of the name where no property matched.
+	unknown string
+}
+
+func (p *methodParser) err(name string) error {
+	if p.unknown == "" {
+		return fmt.Errorf("method (%s) has no property after By or OrderBy", name)
+	}
+	return fmt.Errorf("method (%s): no property matches (%s), expected one of %s",
+		name, p.unknown, strings.Join(p.sortedProperties(), ", "))
+}
+
+func (p *methodParser) sortedProperties() []string {
+	properties := append([]string(nil), p.properties...)
+	sort.Strings(properties)
+	return properties
+}
+
+func (p *methodParser) property(rest string) []string {
+	var matched []string
+	for _, property := range p.properties {
+		if strings.HasPrefix(rest, property) {
+			matched = append(matched, property)
+		}
+	}
+	if len(matched) == 0 && len(rest) < len(p.unknown) {
+		p.unknown = rest
+	}
+	return matched
+}
+
+func (p *methodParser) criteria(rest string) ([][]Criterion, []Order, bool) {
+	for _, property := range p.property(rest) {
+		after := rest[len(property):]
+		for _, keyword := range keywords {
+			if !strings.HasPrefix(after, keyword.Name) {
+				continue
+			}
+			criterion := Criterion{Property: property, Keyword: keyword}
+			tail := after[len(keyword.Name):]
+
+			if tail == "" {
+				return [][]Criterion{{criterion}}, nil, true
+			}
+			if next, ok := strings.CutPrefix(tail, "OrderBy"); ok {
+				if orders, ok := p.orders(next); ok {
+					return [][]Criterion{{criterion}}, orders, true
+				}
+			}
+			if next, ok := strings.CutPrefix(tail, "And"); ok {
+				if criteria, orders, ok := p.criteria(next); ok {
+					criteria[0] = append([]Criterion{criterion}, criteria[0]...)
+					return criteria, orders, true
+				}
+			}
+			if next, ok := strings.CutPrefix(tail, "Or"); ok {
+				if criteria, orders, ok := p.criteria(next); ok {
+					return append([][]Criterion{{criterion}}, criteria...), orders, true
+				}
+			}
+		}
+	}
+	return nil, nil, false
+}
+
+func (p *methodParser) orders(rest string) ([]Order, bool) {
+	for _, property := range p.property(rest) {
+		after := rest[len(property):]
+		for _, direction := range []string{"Desc", "Asc", ""} {
+			tail, ok := strings.CutPrefix(after, direction)
+			if !ok {
+				continue
+			}
+			order := Order{Property: property, Desc: direction == "Desc"}
+			if tail == "" {
+				return []Order{order}, true
+			}
+			if orders, ok := p.orders(tail); ok {
+				return append([]Order{order}, orders...), true
+			}
+		}
+	}
+	return nil, false
+}
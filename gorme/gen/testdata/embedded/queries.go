@@ -0,0 +1,37 @@
+package embedded
+
+import (
+	"context"
+
+	"github.com/raaaaaaaay86/go-persistence-extension/gorme/entity"
+	"gorm.io/gorm"
+)
+
+type Address struct {
+	City   string
+	Street string `gorm:"column:street_name"`
+}
+
+type Customer struct {
+	gorm.Model
+	Name     string
+	Home     Address `gorm:"embedded;embeddedPrefix:home_"`
+	Work     Address `gorm:"embedded"`
+	Manager  *entity.User
+	Orders   []entity.Order
+	Internal string `gorm:"-"`
+}
+
+type CustomerQueries interface {
+	FindByCityAndStreet(ctx context.Context, city string, street string) ([]*Customer, error)
+	FindByNameContaining(ctx context.Context, name string) ([]*Customer, error)
+	DeleteByNameContainingOrCityEndingWith(ctx context.Context, name string, city string) (int64, error)
+}
+
+type AssociationQueries interface {
+	FindByManager(ctx context.Context, manager *entity.User) ([]*Customer, error)
+}
+
+type IgnoredQueries interface {
+	FindByInternal(ctx context.Context, internal string) ([]*Customer, error)
+}
@@ -0,0 +1,19 @@
+package typo
+
+import (
+	"context"
+
+	"github.com/raaaaaaaay86/go-persistence-extension/gorme/entity"
+)
+
+type MisspelledQueries interface {
+	FindByUsernmaeAndAge(ctx context.Context, username string, age int) ([]*entity.User, error)
+}
+
+type MistypedQueries interface {
+	FindByAgeGreaterThan(ctx context.Context, age string) ([]*entity.User, error)
+}
+
+type MiscountedQueries interface {
+	CountByAgeBetween(ctx context.Context, age int) (int64, error)
+}
@@ -0,0 +1,335 @@
+package gen
+
+import (
+	"fmt"
+	"go/ast"
+	"go/types"
+	"strings"
+
+	"github.com/raaaaaaaay86/go-persistence-extension/contract"
+)
+
+var specFuncs = map[contract.Operator]string{
+	contract.OpEq:        "Eq",
+	contract.OpNeq:       "Neq",
+	contract.OpGt:        "Gt",
+	contract.OpGte:       "Gte",
+	contract.OpLt:        "Lt",
+	contract.OpLte:       "Lte",
+	contract.OpIn:        "In",
+	contract.OpNotIn:     "NotIn",
+	contract.OpBetween:   "Between",
+	contract.OpLike:      "Like",
+	contract.OpPrefix:    "Prefix",
+	contract.OpSuffix:    "Suffix",
+	contract.OpContains:  "Contains",
+	contract.OpIsNull:    "IsNull",
+	contract.OpIsNotNull: "IsNotNull",
+}
+
+// param is a parameter of an interface method after the context.
+type param struct {
+	Name     string
+	Expr     ast.Expr
+	Type     types.Type
+	Variadic bool
+}
+
+func (w *writer) writeInterface(iface *ast.InterfaceType) error {
+	obj := w.pkg.Scope().Lookup(w.config.Interface)
+	if obj == nil {
+		return fmt.Errorf("interface (%s) has type errors", w.config.Interface)
+	}
+	typ, ok := obj.Type().Underlying().(*types.Interface)
+	if !ok {
+		return fmt.Errorf("(%s) is not an interface", w.config.Interface)
+	}
+
+	impl := "gpe" + w.config.Interface
+	fmt.Fprintf(&w.body, "// %s implements %s.\n", impl, w.config.Interface)
+	fmt.Fprintf(&w.body, "type %s struct {\n\trepo gorme.Connector\n}\n\n", impl)
+	fmt.Fprintf(&w.body, "// New%s returns the %s running on the database of repo.\n", w.config.Interface, w.config.Interface)
+	fmt.Fprintf(&w.body, "func New%s(repo gorme.Connector) %s {\n\treturn &%s{repo: repo}\n}\n",
+		w.config.Interface, w.config.Interface, impl)
+
+	for _, field := range iface.Methods.List {
+		if len(field.Names) == 0 {
+			return fmt.Errorf("interface (%s): embedded interface (%s) is not supported", w.config.Interface, w.print(field.Type))
+		}
+		name := field.Names[0].Name
+
+		var sig *types.Signature
+		for i := 0; i < typ.NumMethods(); i++ {
+			if typ.Method(i).Name() == name {
+				sig = typ.Method(i).Type().(*types.Signature)
+			}
+		}
+		if sig == nil {
+			return fmt.Errorf("method (%s) has type errors", name)
+		}
+
+		if err := w.writeMethod(impl, name, field.Type.(*ast.FuncType), sig); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (w *writer) writeMethod(impl string, name string, fn *ast.FuncType, sig *types.Signature) error {
+	method, err := ParseMethodName(name, w.propertyNames())
+	if err != nil {
+		return err
+	}
+
+	ctx, params, err := w.params(name, fn, sig)
+	if err != nil {
+		return err
+	}
+	if len(params) != method.Args() {
+		return fmt.Errorf("method (%s) expects %d parameters after the context, got %d", name, method.Args(), len(params))
+	}
+	if err := w.checkResults(method, sig); err != nil {
+		return err
+	}
+
+	var and []string
+	var or []string
+	var guards []string
+	next := 0
+	for _, criteria := range method.Criteria {
+		and = and[:0]
+		for _, criterion := range criteria {
+			spec, err := w.criterion(name, criterion, params[next:next+criterion.Keyword.Args])
+			if err != nil {
+				return err
+			}
+			// An empty pattern matches every row, which must not be deleted by mistake.
+			switch criterion.Keyword.Operator {
+			case contract.OpPrefix, contract.OpSuffix, contract.OpContains:
+				if method.Action == ActionDelete {
+					guards = append(guards, params[next].Name)
+				}
+			}
+			next += criterion.Keyword.Args
+			and = append(and, spec)
+		}
+		or = append(or, join("contract.And", and))
+	}
+	spec := "nil"
+	if len(or) > 0 {
+		spec = join("contract.Or", or)
+	}
+
+	var sorts []string
+	for _, order := range method.Orders {
+		direction := "Asc"
+		if order.Desc {
+			direction = "Desc"
+		}
+		sorts = append(sorts, fmt.Sprintf("contract.%s(%q)", direction, w.properties[order.Property].Column))
+	}
+
+	var signature []string
+	signature = append(signature, ctx+" context.Context")
+	for _, p := range params {
+		typ := w.print(p.Expr)
+		w.useExpr(p.Expr)
+		signature = append(signature, p.Name+" "+typ)
+	}
+	var resultTypes []string
+	for _, field := range fn.Results.List {
+		for i := 0; i < max(len(field.Names), 1); i++ {
+			resultTypes = append(resultTypes, w.print(field.Type))
+		}
+		w.useExpr(field.Type)
+	}
+	results := "(" + strings.Join(resultTypes, ", ") + ")"
+
+	if len(guards) > 0 {
+		if err := w.useImport("errors", "errors"); err != nil {
+			return err
+		}
+	}
+
+	fmt.Fprintf(&w.body, "\nfunc (q *%s) %s(%s) %s {\n", impl, name, strings.Join(signature, ", "), results)
+	for _, guard := range guards {
+		fmt.Fprintf(&w.body, "\tif %s == \"\" {\n", guard)
+		fmt.Fprintf(&w.body, "\t\treturn 0, errors.New(%q)\n\t}\n", fmt.Sprintf("%s: empty %s matches every row", name, guard))
+	}
+	fmt.Fprintf(&w.body, "\tspec := %s\n", spec)
+
+	call := fmt.Sprintf("%s[%s](%s, q.repo, spec", w.helper(method.Action), w.entityExpr, ctx)
+	switch method.Action {
+	case ActionFind:
+		opts := "contract.FindOptions{}"
+		if len(sorts) > 0 {
+			opts = fmt.Sprintf("contract.FindOptions{Sort: []contract.Sort{%s}}", strings.Join(sorts, ", "))
+		}
+		fmt.Fprintf(&w.body, "\treturn %s, %s)\n}\n", call, opts)
+	case ActionGet:
+		for _, sort := range sorts {
+			call += ", " + sort
+		}
+		fmt.Fprintf(&w.body, "\treturn %s)\n}\n", call)
+	default:
+		if len(sorts) > 0 {
+			return fmt.Errorf("method (%s): OrderBy is only supported by Find and Get", name)
+		}
+		fmt.Fprintf(&w.body, "\treturn %s)\n}\n", call)
+	}
+	return nil
+}
+
+// useImport imports the package path as name into the generated file.
+func (w *writer) useImport(name string, path string) error {
+	if existing, ok := w.imports[name]; ok && existing != path {
+		return fmt.Errorf("import (%s) of the interface file conflicts with (%s)", existing, path)
+	}
+	w.imports[name] = path
+	w.used[name] = true
+	return nil
+}
+
+func (w *writer) helper(action Action) string {
+	return "gorme." + string(action) + "BySpec"
+}
+
+// join calls the variadic spec function fn with specs unless there's only one spec.
+func join(fn string, specs []string) string {
+	if len(specs) == 1 {
+		return specs[0]
+	}
+	return fn + "(\n" + strings.Join(specs, ",\n") + ",\n)"
+}
+
+func (w *writer) params(name string, fn *ast.FuncType, sig *types.Signature) (string, []param, error) {
+	var params []param
+	index := 0
+	for _, field := range fn.Params.List {
+		names := field.Names
+		if len(names) == 0 {
+			names = []*ast.Ident{nil}
+		}
+		for _, ident := range names {
+			p := param{Expr: field.Type, Type: sig.Params().At(index).Type()}
+			if ident != nil && ident.Name != "_" {
+				p.Name = ident.Name
+			} else {
+				p.Name = fmt.Sprintf("arg%d", index)
+			}
+			// The generated code declares q and spec.
+			if p.Name == "q" || p.Name == "spec" {
+				p.Name += "Arg"
+			}
+			_, p.Variadic = field.Type.(*ast.Ellipsis)
+			params = append(params, p)
+			index++
+		}
+	}
+
+	if len(params) == 0 || !isContext(params[0].Type) {
+		return "", nil, fmt.Errorf("method (%s) must take context.Context as its first parameter", name)
+	}
+	return params[0].Name, params[1:], nil
+}
+
+func isContext(t types.Type) bool {
+	named, ok := t.(*types.Named)
+	return ok && named.Obj().Pkg() != nil && named.Obj().Pkg().Path() == "context" && named.Obj().Name() == "Context"
+}
+
+func (w *writer) checkResults(method *Method, sig *types.Signature) error {
+	var expected string
+	var ok bool
+
+	results := sig.Results()
+	if results.Len() == 2 && types.Identical(results.At(1).Type(), types.Universe.Lookup("error").Type()) {
+		first := results.At(0).Type()
+		switch method.Action {
+		case ActionFind:
+			slice, isSlice := first.(*types.Slice)
+			ok = isSlice && types.Identical(slice.Elem(), types.NewPointer(w.entity))
+		case ActionGet:
+			ok = types.Identical(first, types.NewPointer(w.entity))
+		case ActionCount, ActionDelete:
+			ok = types.Identical(first, types.Typ[types.Int64])
+		case ActionExists:
+			ok = types.Identical(first, types.Typ[types.Bool])
+		}
+	}
+
+	switch method.Action {
+	case ActionFind:
+		expected = fmt.Sprintf("([]*%s, error)", w.entityExpr)
+	case ActionGet:
+		expected = fmt.Sprintf("(*%s, error)", w.entityExpr)
+	case ActionCount, ActionDelete:
+		expected = "(int64, error)"
+	case ActionExists:
+		expected = "(bool, error)"
+	}
+	if !ok {
+		return fmt.Errorf("method (%s) must return %s", method.Name, expected)
+	}
+	return nil
+}
+
+func (w *writer) criterion(name string, criterion Criterion, params []param) (string, error) {
+	prop := w.properties[criterion.Property]
+	keyword := criterion.Keyword
+	fn := "contract." + specFuncs[keyword.Operator]
+
+	mismatch := func(p param, expected string) error {
+		return fmt.Errorf("method (%s): parameter (%s) of %s%s is %s, expected %s",
+			name, p.Name, criterion.Property, keyword.Name, p.Type, expected)
+	}
+
+	switch keyword.Operator {
+	case contract.OpIsNull, contract.OpIsNotNull:
+		return fmt.Sprintf("%s(%q)", fn, prop.Column), nil
+	case contract.OpIn, contract.OpNotIn:
+		p := params[0]
+		slice, ok := p.Type.Underlying().(*types.Slice)
+		if !ok || !types.AssignableTo(slice.Elem(), prop.Type) {
+			return "", mismatch(p, "[]"+prop.Type.String())
+		}
+		return fmt.Sprintf("%s(%q, %s...)", fn, prop.Column, p.Name), nil
+	case contract.OpLike, contract.OpPrefix, contract.OpSuffix, contract.OpContains:
+		if !isString(prop.Type) {
+			return "", fmt.Errorf("method (%s): %s of %s requires a string property, %s is %s",
+				name, keyword.Name, criterion.Property, criterion.Property, prop.Type)
+		}
+		p := params[0]
+		if !types.AssignableTo(p.Type, types.Typ[types.String]) {
+			return "", mismatch(p, "string")
+		}
+		return fmt.Sprintf("%s(%q, %s)", fn, prop.Column, p.Name), nil
+	}
+
+	if keyword.Args == 0 {
+		if !isBool(prop.Type) {
+			return "", fmt.Errorf("method (%s): %s of %s requires a bool property, %s is %s",
+				name, keyword.Name, criterion.Property, criterion.Property, prop.Type)
+		}
+		return fmt.Sprintf("%s(%q, %s)", fn, prop.Column, keyword.Value), nil
+	}
+
+	var args []string
+	for _, p := range params {
+		if p.Variadic || !types.AssignableTo(p.Type, prop.Type) {
+			return "", mismatch(p, prop.Type.String())
+		}
+		args = append(args, p.Name)
+	}
+	return fmt.Sprintf("%s(%q, %s)", fn, prop.Column, strings.Join(args, ", ")), nil
+}
+
+func isString(t types.Type) bool {
+	basic, ok := t.Underlying().(*types.Basic)
+	return ok && basic.Kind() == types.String
+}
+
+func isBool(t types.Type) bool {
+	basic, ok := t.Underlying().(*types.Basic)
+	return ok && basic.Kind() == types.Bool
+}
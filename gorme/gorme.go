@@ -1,12 +1,25 @@
 package gorme
 
 import (
+	"fmt"
 	"reflect"
+	"strings"
 
 	"github.com/raaaaaaaay86/go-persistence-extension/contract"
+	"github.com/raaaaaaaay86/go-persistence-extension/gorme/macro"
 	"github.com/raaaaaaaay86/go-persistence-extension/gorme/util"
+	"gorm.io/gorm"
 )
 
+// Connector is implemented by the gorme repositories. It exposes the *gorm.DB of
+// a repository to the package-level generic functions.
+type Connector interface {
+	DB() *gorm.DB
+}
+
+// ToQueryMap converts object into a contract.QueryMap matching each non-nil field
+// by equality on its column. The gpe tags are not applied, use ParseFilter for a
+// filter struct with gpe tags.
 func ToQueryMap(object any) contract.QueryMap {
 	m := make(map[string]interface{})
 
@@ -33,13 +46,183 @@ func ToQueryMap(object any) contract.QueryMap {
 			value = &dereferenced
 		}
 
-		tagInfo := util.ParseGormTag(field.Tag.Get("gorm"))
-		if tagInfo != nil {
-			m[tagInfo.ColumnName] = value.Interface()
-		} else {
-			m[util.ToSnakeCase(field.Name)] = value.Interface()
-		}
+		m[util.ColumnName(field)] = value.Interface()
 	}
 
 	return m
-}
\ No newline at end of file
+}
+
+// ParseFilter converts a filter struct into a contract.QueryMap. Nil pointer and
+// nil slice fields are skipped. A field without gpe tag is matched by equality,
+// otherwise the tag decides the column and the operator of the field. Two fields
+// of the same column and operator are rejected, as one would override the other.
+//
+// - column: the column name. Default is the gorm column of the field.
+// - op: one of the contract.Operator (eq, neq, gt, gte, lt, lte, in, not_in, between,
+// like, ilike, prefix, suffix, contains, is_null, is_not_null). is_null and is_not_null expect a bool field.
+// - match: contains, prefix, suffix or exact for like and ilike. Wildcards in the value are escaped.
+// - path: the dot separated path of a json column. The field is matched against the value at the path,
+// the json operators (json_contains, json_has_key...) are also accepted.
+//
+//	type UserFilter struct {
+//		Username *string `gpe:"op=like,match=prefix"`
+//		MinAge   *int    `gpe:"column=age,op=gte"`
+//		MaxAge   *int    `gpe:"column=age,op=lte"`
+//		Emails   []string `gpe:"column=email,op=in"`
+//		Theme    *string  `gpe:"column=settings,path=display.theme"`
+//	}
+//
+//	query, err := ParseFilter(UserFilter{MinAge: &minAge})
+//	results, err := FindBy(ctx, query, -1)
+func ParseFilter(object any) (contract.QueryMap, error) {
+	m := make(map[string]interface{})
+	owners := make(map[string]string)
+	set := func(key string, name string, value any) error {
+		if owner, ok := owners[key]; ok {
+			return fmt.Errorf("fields (%s) and (%s) have the same filter key (%s)", owner, name, key)
+		}
+		owners[key] = name
+		m[key] = value
+		return nil
+	}
+
+	v := reflect.ValueOf(object)
+
+	if v.Kind() == reflect.Ptr {
+		v = v.Elem()
+	}
+
+	if v.Kind() != reflect.Struct {
+		return m, nil
+	}
+
+	fields, values := util.FlattenStruct(v)
+	for i := 0; i < len(fields); i++ {
+		field := fields[i]
+		value := values[i]
+
+		switch value.Kind() {
+		case reflect.Ptr:
+			if value.IsNil() {
+				continue
+			}
+			dereferenced := value.Elem()
+			value = &dereferenced
+		case reflect.Slice:
+			if value.IsNil() {
+				continue
+			}
+		}
+
+		tagInfo, err := util.ParseFilterTag(field.Tag.Get("gpe"))
+		if err != nil {
+			return nil, fmt.Errorf("field (%s): %w", field.Name, err)
+		}
+		if tagInfo != nil && tagInfo.Ignored {
+			continue
+		}
+
+		column := util.ColumnName(field)
+		if tagInfo != nil && tagInfo.ColumnName != "" {
+			column = tagInfo.ColumnName
+		}
+
+		if tagInfo != nil && len(tagInfo.Path) > 0 {
+			predicate, err := filterJSONPredicate(column, tagInfo, value.Interface())
+			if err != nil {
+				return nil, fmt.Errorf("field (%s): %w", field.Name, err)
+			}
+			key := fmt.Sprintf("%s.%s:%s", column, strings.Join(tagInfo.Path, "."), predicate.Operator)
+			if err := set(key, field.Name, predicate); err != nil {
+				return nil, err
+			}
+			continue
+		}
+
+		if tagInfo == nil || tagInfo.Operator == "" || tagInfo.Operator == string(contract.OpEq) {
+			if err := set(column, field.Name, value.Interface()); err != nil {
+				return nil, err
+			}
+			continue
+		}
+
+		predicate, err := filterPredicate(column, tagInfo, value.Interface())
+		if err != nil {
+			return nil, fmt.Errorf("field (%s): %w", field.Name, err)
+		}
+		if err := set(fmt.Sprintf("%s:%s", column, tagInfo.Operator), field.Name, predicate); err != nil {
+			return nil, err
+		}
+	}
+
+	return m, nil
+}
+
+func filterJSONPredicate(column string, tagInfo *util.FilterTagInfo, value any) (contract.JSONPredicate, error) {
+	info := *tagInfo
+	if info.Operator == "" {
+		info.Operator = string(contract.OpEq)
+	}
+	op := contract.Operator(info.Operator)
+
+	if macro.IsJSONOperator(op) {
+		if info.Match != "" {
+			return contract.JSONPredicate{}, fmt.Errorf("match (%s) is only supported by like and ilike", info.Match)
+		}
+		return contract.JSONPredicate{Column: column, Path: info.Path, Operator: op, Value: value}, nil
+	}
+
+	predicate, err := filterPredicate(column, &info, value)
+	if err != nil {
+		return contract.JSONPredicate{}, err
+	}
+	return contract.JSONPredicate{
+		Column:   column,
+		Path:     info.Path,
+		Operator: predicate.Operator,
+		Value:    predicate.Value,
+	}, nil
+}
+
+func filterPredicate(column string, tagInfo *util.FilterTagInfo, value any) (contract.Predicate, error) {
+	op := contract.Operator(tagInfo.Operator)
+	if _, err := macro.OperatorOf(op); err != nil {
+		return contract.Predicate{}, err
+	}
+
+	if tagInfo.Match != "" && op != contract.OpLike && op != contract.OpILike {
+		return contract.Predicate{}, fmt.Errorf("match (%s) is only supported by like and ilike", tagInfo.Match)
+	}
+
+	switch op {
+	case contract.OpIsNull, contract.OpIsNotNull:
+		flag, ok := value.(bool)
+		if !ok {
+			return contract.Predicate{}, fmt.Errorf("operator (%s) expects bool field, got (%T)", op, value)
+		}
+		if !flag {
+			if op == contract.OpIsNull {
+				op = contract.OpIsNotNull
+			} else {
+				op = contract.OpIsNull
+			}
+		}
+		return contract.Predicate{Column: column, Operator: op}, nil
+	case contract.OpLike, contract.OpILike:
+		str, ok := value.(string)
+		if !ok {
+			return contract.Predicate{}, fmt.Errorf("match (%s) expects string field, got (%T)", tagInfo.Match, value)
+		}
+		if tagInfo.Match == "" {
+			value = str
+			break
+		}
+		pattern, err := macro.LikePattern(contract.MatchMode(tagInfo.Match), str)
+		if err != nil {
+			return contract.Predicate{}, err
+		}
+		value = pattern
+	}
+
+	return contract.Predicate{Column: column, Operator: op, Value: value}, nil
+}
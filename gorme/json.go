@@ -0,0 +1,82 @@
+package gorme
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/schema"
+)
+
+// JSON maps a jsonb column to the Go value Data, which is usually a struct or
+// a map. It's encoded by encoding/json on write and decoded on read.
+//
+//	type Profile struct {
+//		gorm.Model
+//		Settings gorme.JSON[ProfileSettings]
+//	}
+//
+//	profile := Profile{Settings: gorme.NewJSON(ProfileSettings{Theme: "dark"})}
+type JSON[T any] struct {
+	Data T
+}
+
+func NewJSON[T any](data T) JSON[T] {
+	return JSON[T]{Data: data}
+}
+
+// Value implements driver.Valuer.
+func (j JSON[T]) Value() (driver.Value, error) {
+	encoded, err := json.Marshal(j.Data)
+	if err != nil {
+		return nil, err
+	}
+	return string(encoded), nil
+}
+
+// Scan implements sql.Scanner. NULL is scanned as the zero value of T.
+func (j *JSON[T]) Scan(src any) error {
+	var data T
+
+	switch v := src.(type) {
+	case nil:
+		j.Data = data
+		return nil
+	case []byte:
+		if err := json.Unmarshal(v, &data); err != nil {
+			return err
+		}
+	case string:
+		if err := json.Unmarshal([]byte(v), &data); err != nil {
+			return err
+		}
+	default:
+		return fmt.Errorf("unsupported json source type (%T)", src)
+	}
+
+	j.Data = data
+	return nil
+}
+
+// MarshalJSON encodes Data only, so JSON is transparent in API responses.
+func (j JSON[T]) MarshalJSON() ([]byte, error) {
+	return json.Marshal(j.Data)
+}
+
+func (j *JSON[T]) UnmarshalJSON(b []byte) error {
+	return json.Unmarshal(b, &j.Data)
+}
+
+// GormDataType implements schema.GormDataTypeInterface.
+func (JSON[T]) GormDataType() string {
+	return "json"
+}
+
+// GormDBDataType implements migrator.GormDataTypeInterface.
+func (JSON[T]) GormDBDataType(db *gorm.DB, field *schema.Field) string {
+	if db.Dialector.Name() == "postgres" {
+		return "jsonb"
+	}
+	return "json"
+}
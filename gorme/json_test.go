@@ -0,0 +1,153 @@
+package gorme_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/raaaaaaaay86/go-persistence-extension/contract"
+	"github.com/raaaaaaaay86/go-persistence-extension/gorme"
+	"github.com/raaaaaaaay86/go-persistence-extension/gorme/entity"
+	"github.com/raaaaaaaay86/go-persistence-extension/gorme/repository"
+	"github.com/raaaaaaaay86/go-persistence-extension/gorme/util"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/gorm"
+)
+
+type JSONOperationTestSuite struct {
+	suite.Suite
+	ProfileRepository *repository.ProfileRepository
+}
+
+func (s *JSONOperationTestSuite) SetupTest() {
+	if err := s.Setup(); err != nil {
+		s.T().Fatalf("failed to setup JSONOperationTestSuite: %s", err.Error())
+	}
+}
+
+func (s *JSONOperationTestSuite) Setup() error {
+	db, err := util.CreateGormPostgreSqlConnection(&gorm.Config{TranslateError: true})
+	if err != nil {
+		return err
+	}
+
+	s.ProfileRepository = repository.NewProfileRepository(db.Debug())
+
+	return nil
+}
+
+func (s *JSONOperationTestSuite) Test_FindByJSONPath() {
+	ctx := context.Background()
+
+	s.T().Log("Test_FindByJSONPath: Find profiles with dark theme")
+	results, err := s.ProfileRepository.Find(ctx, contract.JSON("settings", "theme").Eq("dark"), contract.FindOptions{})
+	assert.NoError(s.T(), err)
+	assert.Len(s.T(), results, 2)
+	for _, profile := range results {
+		assert.Equal(s.T(), "dark", profile.Settings.Data.Theme)
+	}
+
+	s.T().Log("Test_FindByJSONPath: Find profiles with level greater than 2")
+	results, err = s.ProfileRepository.Find(ctx, contract.JSON("settings", "level").Gt(2), contract.FindOptions{})
+	assert.NoError(s.T(), err)
+	assert.Len(s.T(), results, 2)
+	for _, profile := range results {
+		assert.Greater(s.T(), profile.Settings.Data.Level, 2)
+	}
+
+	s.T().Log("Test_FindByJSONPath: Find profiles with email notification enabled")
+	results, err = s.ProfileRepository.Find(ctx, contract.JSON("settings", "notification", "email").Eq(true), contract.FindOptions{})
+	assert.NoError(s.T(), err)
+	assert.Len(s.T(), results, 2)
+	for _, profile := range results {
+		assert.True(s.T(), profile.Settings.Data.Notification.Email)
+	}
+
+	s.T().Log("Test_FindByJSONPath: Find profiles without notification settings")
+	results, err = s.ProfileRepository.Find(ctx, contract.JSON("settings", "notification").IsNull(), contract.FindOptions{})
+	assert.NoError(s.T(), err)
+	assert.Len(s.T(), results, 1)
+	assert.Nil(s.T(), results[0].Settings.Data.Notification)
+
+	s.T().Log("Test_FindByJSONPath: Comparison without path")
+	_, err = s.ProfileRepository.Find(ctx, contract.JSON("settings").Eq("dark"), contract.FindOptions{})
+	assert.Error(s.T(), err)
+}
+
+func (s *JSONOperationTestSuite) Test_FindByJSONOperator() {
+	ctx := context.Background()
+
+	s.T().Log("Test_FindByJSONOperator: Find profiles containing the vip tag")
+	results, err := s.ProfileRepository.Find(ctx, contract.JSON("settings", "tags").Contains("vip"), contract.FindOptions{})
+	assert.NoError(s.T(), err)
+	assert.Len(s.T(), results, 1)
+	assert.Contains(s.T(), results[0].Settings.Data.Tags, "vip")
+
+	s.T().Log("Test_FindByJSONOperator: Find profiles containing a sub document")
+	results, err = s.ProfileRepository.Find(ctx, contract.JSON("settings").Contains(map[string]any{"theme": "light", "level": 1}), contract.FindOptions{})
+	assert.NoError(s.T(), err)
+	assert.Len(s.T(), results, 1)
+
+	s.T().Log("Test_FindByJSONOperator: Find profiles having the tags key")
+	results, err = s.ProfileRepository.Find(ctx, contract.JSON("settings").HasKey("tags"), contract.FindOptions{})
+	assert.NoError(s.T(), err)
+	assert.Len(s.T(), results, 3)
+
+	s.T().Log("Test_FindByJSONOperator: Find profiles by json path with variables")
+	results, err = s.ProfileRepository.Find(
+		ctx,
+		contract.JSON("settings").PathExists("$.level ? (@ >= $min)", map[string]any{"min": 3}),
+		contract.FindOptions{},
+	)
+	assert.NoError(s.T(), err)
+	assert.Len(s.T(), results, 2)
+}
+
+func (s *JSONOperationTestSuite) Test_FindByJSONFilter() {
+	ctx := context.Background()
+
+	s.T().Log("Test_FindByJSONFilter: Find dark theme profiles with level at least 5")
+	filter := entity.ProfileFilter{Theme: &[]string{"dark"}[0], MinLevel: &[]int{5}[0]}
+	query, err := filter.ToMap()
+	assert.NoError(s.T(), err)
+	results, err := s.ProfileRepository.FindBy(ctx, query, -1)
+	assert.NoError(s.T(), err)
+	assert.Len(s.T(), results, 1)
+	assert.Equal(s.T(), 5, results[0].Settings.Data.Level)
+
+	s.T().Log("Test_FindByJSONFilter: Find profiles tagged beta")
+	filter = entity.ProfileFilter{Tag: &[]string{"beta"}[0]}
+	query, err = filter.ToMap()
+	assert.NoError(s.T(), err)
+	results, err = s.ProfileRepository.FindBy(ctx, query, -1)
+	assert.NoError(s.T(), err)
+	assert.Len(s.T(), results, 2)
+}
+
+func (s *JSONOperationTestSuite) Test_CreateWithJSON() {
+	ctx := context.Background()
+
+	s.T().Log("Test_CreateWithJSON: Create a profile and read its settings back")
+	profile := &entity.Profile{
+		UserID: 5,
+		Settings: gorme.NewJSON(entity.ProfileSettings{
+			Theme:        "solarized",
+			Level:        7,
+			Tags:         []string{"created"},
+			Notification: &entity.NotificationSettings{Email: true},
+		}),
+	}
+	err := s.ProfileRepository.Create(ctx, profile)
+	assert.NoError(s.T(), err)
+
+	found, err := s.ProfileRepository.GetById(ctx, profile.ID)
+	assert.NoError(s.T(), err)
+	assert.Equal(s.T(), profile.Settings.Data, found.Settings.Data)
+
+	_, err = s.ProfileRepository.DeleteById(ctx, profile.ID)
+	assert.NoError(s.T(), err)
+}
+
+func TestJSONOperationTestSuite(t *testing.T) {
+	suite.Run(t, new(JSONOperationTestSuite))
+}
@@ -0,0 +1,212 @@
+package macro
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/raaaaaaaay86/go-persistence-extension/contract"
+	"github.com/raaaaaaaay86/go-persistence-extension/gorme/util"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/schema"
+)
+
+// CountBy counts the records of T matched by the query.
+func CountBy[T any](ctx context.Context, db *gorm.DB, query contract.QueryMap) (int64, error) {
+	var entity T
+	var total int64
+
+	db, err := WhereQueryMap(WithContext(ctx, db).Model(&entity), query)
+	if err != nil {
+		return 0, err
+	}
+
+	if err := db.Count(&total).Error; err != nil {
+		return 0, err
+	}
+
+	return total, nil
+}
+
+// AggregateField computes the aggregate function over the only non-zero field of entity.
+func AggregateField[T any](
+	ctx context.Context,
+	db *gorm.DB,
+	fn contract.AggregateFunc,
+	entity contract.Selector,
+	query contract.QueryMap,
+) (float64, error) {
+	field, err := util.ParseMarkedField(entity)
+	if err != nil {
+		return 0, err
+	}
+
+	expr, err := aggregateExpr(fn, field.ColumnName)
+	if err != nil {
+		return 0, err
+	}
+
+	var model T
+	db, err = WhereQueryMap(WithContext(ctx, db).Model(&model), query)
+	if err != nil {
+		return 0, err
+	}
+
+	var result sql.NullFloat64
+	if err := db.Clauses(clause.Select{Expression: expr}).Row().Scan(&result); err != nil {
+		return 0, err
+	}
+
+	return result.Float64, nil
+}
+
+// GroupBy groups the records of T by the only non-zero field of entity and computes
+// the aggregates of each group. Rows are ordered by the group key.
+func GroupBy[T any](
+	ctx context.Context,
+	db *gorm.DB,
+	entity contract.Selector,
+	query contract.QueryMap,
+	aggregates ...contract.Aggregate,
+) ([]contract.GroupRow, error) {
+	field, err := util.ParseMarkedField(entity)
+	if err != nil {
+		return nil, err
+	}
+
+	s, err := Schema[T](db)
+	if err != nil {
+		return nil, err
+	}
+
+	exprs, err := aggregateExprs(s, aggregates)
+	if err != nil {
+		return nil, err
+	}
+
+	key := clause.Column{Table: clause.CurrentTable, Name: field.ColumnName}
+	sqls := []string{"?"}
+	vars := []any{key}
+	for _, expr := range exprs {
+		sqls = append(sqls, "?")
+		vars = append(vars, expr)
+	}
+
+	var model T
+	tx, err := WhereQueryMap(WithContext(ctx, db).Model(&model), query)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := tx.
+		Clauses(clause.Select{Expression: clause.Expr{SQL: strings.Join(sqls, ", "), Vars: vars}}).
+		Clauses(clause.GroupBy{Columns: []clause.Column{key}}).
+		Clauses(clause.OrderBy{Columns: []clause.OrderByColumn{{Column: key}}}).
+		Rows()
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var results []contract.GroupRow
+	for rows.Next() {
+		keyPtr := reflect.New(reflect.PointerTo(field.ReflectType))
+		values := make([]sql.NullFloat64, len(aggregates))
+
+		dest := []any{keyPtr.Interface()}
+		for i := range values {
+			dest = append(dest, &values[i])
+		}
+		if err := rows.Scan(dest...); err != nil {
+			return nil, err
+		}
+
+		row := contract.GroupRow{Values: make([]float64, len(values))}
+		if !keyPtr.Elem().IsNil() {
+			row.Key = keyPtr.Elem().Elem().Interface()
+		}
+		for i, value := range values {
+			row.Values[i] = value.Float64
+		}
+		results = append(results, row)
+	}
+
+	return results, rows.Err()
+}
+
+// aggregateExprs returns the expressions of the aggregates on the columns of s.
+func aggregateExprs(s *schema.Schema, aggregates []contract.Aggregate) ([]clause.Expression, error) {
+	var exprs []clause.Expression
+	for _, aggregate := range aggregates {
+		column := ""
+		if aggregate.Func != contract.AggregateCount {
+			f := s.LookUpField(aggregate.Column)
+			if f == nil || f.DBName == "" {
+				return nil, fmt.Errorf("unknown aggregate column (%s) of %s", aggregate.Column, s.Name)
+			}
+			column = f.DBName
+		}
+
+		expr, err := aggregateExpr(aggregate.Func, column)
+		if err != nil {
+			return nil, err
+		}
+		exprs = append(exprs, expr)
+	}
+	return exprs, nil
+}
+
+func aggregateExpr(fn contract.AggregateFunc, column string) (clause.Expression, error) {
+	switch fn {
+	case contract.AggregateCount:
+		if column == "" {
+			return clause.Expr{SQL: "COUNT(*)"}, nil
+		}
+		return clause.Expr{SQL: "COUNT(?)", Vars: []any{clause.Column{Table: clause.CurrentTable, Name: column}}}, nil
+	case contract.AggregateSum, contract.AggregateAvg, contract.AggregateMin, contract.AggregateMax:
+		return clause.Expr{
+			SQL:  fmt.Sprintf("%s(?)", fn),
+			Vars: []any{clause.Column{Table: clause.CurrentTable, Name: column}},
+		}, nil
+	default:
+		return nil, fmt.Errorf("unsupported aggregate function (%s)", fn)
+	}
+}
+
+// ExistsBy reports whether any record of T is matched by the query. Only the
+// first matched row is read.
+func ExistsBy[T any](ctx context.Context, db *gorm.DB, query contract.QueryMap) (bool, error) {
+	expr, err := BuildQueryMap(query)
+	if err != nil {
+		return false, err
+	}
+	return Exists[T](ctx, db, expr)
+}
+
+// ExistsById reports whether the record of T with the primary key exists.
+func ExistsById[T any, Q contract.Identifier](ctx context.Context, db *gorm.DB, id Q) (bool, error) {
+	return Exists[T](ctx, db, clause.Eq{Column: clause.PrimaryColumn, Value: id})
+}
+
+// Exists reports whether any record of T is matched by expr. A nil expr matches every record.
+func Exists[T any](ctx context.Context, db *gorm.DB, expr clause.Expression) (bool, error) {
+	var entity T
+	var found []int
+
+	tx := WithContext(ctx, db).Model(&entity)
+	if expr != nil {
+		tx = tx.Where(expr)
+	}
+
+	if err := tx.
+		Clauses(clause.Select{Expression: clause.Expr{SQL: "1"}}).
+		Limit(1).
+		Scan(&found).Error; err != nil {
+		return false, err
+	}
+
+	return len(found) > 0, nil
+}
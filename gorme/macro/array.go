@@ -0,0 +1,61 @@
+package macro
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// ArrayLiteral encodes a slice or an array into a PostgreSQL array literal, e.g. {"a","b"}.
+// The literal is bound as a single value, PostgreSQL infers its type from the compared column.
+// A []any is encoded by the type of its first element.
+func ArrayLiteral(value any) (string, error) {
+	values, err := typedSlice(value)
+	if err != nil {
+		return "", err
+	}
+
+	m := pgtype.NewMap()
+	t, ok := m.TypeForValue(values)
+	if !ok {
+		return "", fmt.Errorf("unsupported array type (%T)", value)
+	}
+
+	buf, err := m.Encode(t.OID, pgtype.TextFormatCode, values, nil)
+	if err != nil {
+		return "", err
+	}
+	return string(buf), nil
+}
+
+// ScanArray scans a PostgreSQL array into dest, which must be a pointer to a slice.
+func ScanArray(src any, dest any) error {
+	return pgtype.NewMap().SQLScanner(dest).Scan(src)
+}
+
+// typedSlice converts value into a []E, where E is the element type.
+func typedSlice(value any) (any, error) {
+	v := reflect.Indirect(reflect.ValueOf(value))
+	if v.Kind() != reflect.Slice && v.Kind() != reflect.Array {
+		return nil, fmt.Errorf("expected a slice or an array, got (%T)", value)
+	}
+
+	elem := v.Type().Elem()
+	if elem.Kind() == reflect.Interface {
+		if v.Len() == 0 {
+			return []string{}, nil
+		}
+		elem = reflect.Indirect(v.Index(0).Elem()).Type()
+	}
+
+	s := reflect.MakeSlice(reflect.SliceOf(elem), v.Len(), v.Len())
+	for i := 0; i < v.Len(); i++ {
+		item := reflect.Indirect(reflect.ValueOf(v.Index(i).Interface()))
+		if !item.IsValid() || !item.Type().ConvertibleTo(elem) {
+			return nil, fmt.Errorf("array element (%v) is not (%s)", v.Index(i).Interface(), elem)
+		}
+		s.Index(i).Set(item.Convert(elem))
+	}
+	return s.Interface(), nil
+}
@@ -0,0 +1,147 @@
+package macro
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/raaaaaaaay86/go-persistence-extension/contract"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/schema"
+)
+
+const associationDepthKey = "gpe:association_depth"
+
+// AssociationCondition matches the records having a related record of the relation
+// matched by spec. It's rendered as an EXISTS subquery correlated by the gorm
+// relationship of the statement schema, so it works for has-one, has-many,
+// belongs-to and many-to-many relations.
+//
+// Unlike a JOIN, the subquery never repeats a record having several matched related
+// records. Find, Count and the pagination totals are correct without DISTINCT.
+//
+//	// EXISTS (SELECT 1 FROM "orders" "orders_1" WHERE "orders_1"."user_id" = "users"."id"
+//	//	AND "orders_1"."deleted_at" IS NULL AND ("orders_1"."amount" > 100))
+//	expr, err := AssociationCondition("Orders", contract.Gt("amount", 100))
+func AssociationCondition(relation string, spec contract.Spec) (clause.Expression, error) {
+	if relation == "" {
+		return nil, errors.New("no relation specified")
+	}
+
+	where, err := BuildSpec(spec)
+	if err != nil {
+		return nil, err
+	}
+
+	names := strings.Split(relation, ".")
+	expr := associationExpr{relation: names[len(names)-1], where: where}
+	for i := len(names) - 2; i >= 0; i-- {
+		expr = associationExpr{relation: names[i], where: expr}
+	}
+	return expr, nil
+}
+
+type associationExpr struct {
+	relation string
+	where    clause.Expression
+}
+
+// Build implements clause.Expression. The relation is resolved against the schema
+// of the statement, where is built with the related table as the current table,
+// so the columns of its predicates are qualified by the alias of the related table.
+func (e associationExpr) Build(builder clause.Builder) {
+	stmt, ok := builder.(*gorm.Statement)
+	if !ok || stmt.Schema == nil {
+		builder.AddError(fmt.Errorf("relation (%s) requires a model", e.relation))
+		return
+	}
+
+	rel, ok := stmt.Schema.Relationships.Relations[e.relation]
+	if !ok {
+		builder.AddError(fmt.Errorf("unknown relation (%s) of %s", e.relation, stmt.Schema.Name))
+		return
+	}
+
+	depth, _ := stmt.Settings.Load(associationDepthKey)
+	level, _ := depth.(int)
+	level++
+
+	related := rel.FieldSchema
+	alias := fmt.Sprintf("%s_%d", related.Table, level)
+	column := func(table string, field *schema.Field) clause.Column {
+		return clause.Column{Table: table, Name: field.DBName}
+	}
+
+	var conds []clause.Expression
+	builder.WriteString("EXISTS (SELECT 1 FROM ")
+	if rel.JoinTable != nil {
+		joinAlias := fmt.Sprintf("%s_%d", rel.JoinTable.Table, level)
+		builder.WriteQuoted(clause.Table{Name: rel.JoinTable.Table, Alias: joinAlias})
+		builder.WriteString(" JOIN ")
+		builder.WriteQuoted(clause.Table{Name: related.Table, Alias: alias})
+		builder.WriteString(" ON ")
+
+		var on []clause.Expression
+		for _, ref := range rel.References {
+			if ref.OwnPrimaryKey {
+				conds = append(conds, clause.Eq{
+					Column: column(joinAlias, ref.ForeignKey),
+					Value:  column(clause.CurrentTable, ref.PrimaryKey),
+				})
+			} else {
+				on = append(on, clause.Eq{
+					Column: column(joinAlias, ref.ForeignKey),
+					Value:  column(alias, ref.PrimaryKey),
+				})
+			}
+		}
+		clause.AndConditions{Exprs: on}.Build(builder)
+	} else {
+		builder.WriteQuoted(clause.Table{Name: related.Table, Alias: alias})
+		for _, ref := range rel.References {
+			switch {
+			case ref.PrimaryKey == nil:
+				// The type column of a polymorphic relation.
+				conds = append(conds, clause.Eq{Column: column(alias, ref.ForeignKey), Value: ref.PrimaryValue})
+			case ref.OwnPrimaryKey:
+				conds = append(conds, clause.Eq{
+					Column: column(alias, ref.ForeignKey),
+					Value:  column(clause.CurrentTable, ref.PrimaryKey),
+				})
+			default:
+				conds = append(conds, clause.Eq{
+					Column: column(alias, ref.PrimaryKey),
+					Value:  column(clause.CurrentTable, ref.ForeignKey),
+				})
+			}
+		}
+	}
+
+	for _, field := range related.Fields {
+		if field.DBName != "" && field.FieldType == reflect.TypeOf(gorm.DeletedAt{}) {
+			conds = append(conds, clause.Eq{Column: column(alias, field), Value: nil})
+		}
+	}
+
+	builder.WriteString(" WHERE ")
+	clause.AndConditions{Exprs: conds}.Build(builder)
+
+	if e.where != nil {
+		builder.WriteString(" AND (")
+
+		table, tableExpr, owner := stmt.Table, stmt.TableExpr, stmt.Schema
+		stmt.Table, stmt.TableExpr, stmt.Schema = alias, nil, related
+		stmt.Settings.Store(associationDepthKey, level)
+
+		e.where.Build(builder)
+
+		stmt.Table, stmt.TableExpr, stmt.Schema = table, tableExpr, owner
+		stmt.Settings.Store(associationDepthKey, level-1)
+
+		builder.WriteString(")")
+	}
+
+	builder.WriteString(")")
+}
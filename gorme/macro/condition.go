@@ -0,0 +1,116 @@
+package macro
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/raaaaaaaay86/go-persistence-extension/gorme/macro/operator"
+	"gorm.io/gorm/clause"
+)
+
+// Condition renders "column operator value" with the SQL shape required by the operator.
+//
+// - IN, NOT_IN: value must be a slice or an array.
+// - BETWEEN: value must be a slice or an array with exactly 2 elements.
+// - IS_NULL, IS_NOT_NULL: value is ignored.
+// - PREFIX, SUFFIX, CONTAINS: value must be a string. Wildcards in value are escaped.
+// - ARRAY_CONTAINS, ARRAY_CONTAINED_BY, ARRAY_OVERLAP: value must be a slice or an array, it's bound as one array.
+// - ANY: value is an element of the array column.
+func Condition(column string, op operator.Enum, value any) (clause.Expression, error) {
+	return ConditionOf(clause.Column{Name: column}, op, value)
+}
+
+// currentColumn qualifies column with the current table of the statement, unless
+// it's already qualified by a table, so it can't bind to a column of another
+// table of the query, e.g. the outer table of a subquery.
+func currentColumn(column string) clause.Column {
+	if strings.Contains(column, ".") {
+		return clause.Column{Name: column}
+	}
+	return clause.Column{Table: clause.CurrentTable, Name: column}
+}
+
+// ConditionOf is like Condition but col can be either a clause.Column or a clause.Expr.
+func ConditionOf(col any, op operator.Enum, value any) (clause.Expression, error) {
+	f := fmt.Sprintf
+
+	switch op {
+	case operator.EQ:
+		return clause.Eq{Column: col, Value: value}, nil
+	case operator.NEQ:
+		return clause.Neq{Column: col, Value: value}, nil
+	case operator.GT, operator.GTE, operator.LT, operator.LTE, operator.LIKE, operator.ILIKE:
+		return clause.Expr{SQL: f("? %s ?", op), Vars: []any{col, value}}, nil
+	case operator.IN, operator.NOT_IN:
+		values, err := ToValues(value)
+		if err != nil {
+			return nil, err
+		}
+		if len(values) == 0 {
+			if op == operator.IN {
+				return clause.Expr{SQL: "1 = 0"}, nil
+			}
+			return clause.Expr{SQL: "1 = 1"}, nil
+		}
+		return clause.Expr{SQL: f("? %s ?", op), Vars: []any{col, values}}, nil
+	case operator.BETWEEN:
+		values, err := ToValues(value)
+		if err != nil {
+			return nil, err
+		}
+		if len(values) != 2 {
+			return nil, fmt.Errorf("operator (%s) expects 2 values, got %d", op, len(values))
+		}
+		return clause.Expr{SQL: "? BETWEEN ? AND ?", Vars: []any{col, values[0], values[1]}}, nil
+	case operator.IS_NULL, operator.IS_NOT_NULL:
+		return clause.Expr{SQL: f("? %s", op), Vars: []any{col}}, nil
+	case operator.PREFIX, operator.SUFFIX, operator.CONTAINS:
+		str, ok := value.(string)
+		if !ok {
+			return nil, fmt.Errorf("operator (%s) expects string value, got (%T)", op, value)
+		}
+		switch op {
+		case operator.PREFIX:
+			str = EscapeLike(str) + "%"
+		case operator.SUFFIX:
+			str = "%" + EscapeLike(str)
+		default:
+			str = "%" + EscapeLike(str) + "%"
+		}
+		return clause.Expr{SQL: "? LIKE ?", Vars: []any{col, str}}, nil
+	case operator.ARRAY_CONTAINS, operator.ARRAY_CONTAINED_BY, operator.ARRAY_OVERLAP:
+		literal, err := ArrayLiteral(value)
+		if err != nil {
+			return nil, err
+		}
+		return clause.Expr{SQL: f("? %s ?", op), Vars: []any{col, literal}}, nil
+	case operator.ANY:
+		return clause.Expr{SQL: "? = ANY(?)", Vars: []any{value, col}}, nil
+	default:
+		return nil, fmt.Errorf("unsupported operator (%s)", op)
+	}
+}
+
+// ToValues converts a slice or an array into []any.
+func ToValues(value any) ([]any, error) {
+	if values, ok := value.([]any); ok {
+		return values, nil
+	}
+
+	v := reflect.ValueOf(value)
+	if v.Kind() != reflect.Slice && v.Kind() != reflect.Array {
+		return nil, fmt.Errorf("expected a slice or an array, got (%T)", value)
+	}
+
+	values := make([]any, v.Len())
+	for i := 0; i < v.Len(); i++ {
+		values[i] = v.Index(i).Interface()
+	}
+	return values, nil
+}
+
+// EscapeLike escapes the LIKE wildcards (%, _) and the escape character itself.
+func EscapeLike(str string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(str)
+}
@@ -0,0 +1,360 @@
+package macro
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"database/sql/driver"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/raaaaaaaay86/go-persistence-extension/contract"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/schema"
+)
+
+// cursorKey is a column of the keyset, the sort columns followed by the primary keys.
+type cursorKey struct {
+	field *schema.Field
+	desc  bool
+}
+
+// cursorToken is the signed payload of a cursor.
+//
+// - Backward: the cursor requests the rows before Values instead of after.
+// - Inclusive: the rows requested include the boundary row.
+// - Keys: the keyset the cursor was issued for, e.g. "users:age DESC,id DESC".
+// - Values: the keyset values of the boundary row.
+type cursorToken struct {
+	Backward  bool              `json:"b,omitempty"`
+	Inclusive bool              `json:"i,omitempty"`
+	Keys      string            `json:"k"`
+	Values    []json.RawMessage `json:"v"`
+}
+
+// CursorFind returns the page of size records matched by where (nil matches all)
+// which follows cursor, or precedes it for the PrevCursor of a page. The keyset is
+// the sorts followed by the primary keys of T, which are ordered in the direction
+// of the last sort. The cursors are signed by secret with HMAC-SHA256.
+//
+//	-- CursorFind(ctx, db, secret, nil, page.NextCursor, 20, contract.Desc("age"))
+//	SELECT * FROM users WHERE (age, id) < (21, 8) ORDER BY age DESC, id DESC LIMIT 21
+func CursorFind[T any](
+	ctx context.Context,
+	db *gorm.DB,
+	secret []byte,
+	where clause.Expression,
+	cursor string,
+	size int,
+	sorts ...contract.Sort,
+) (*contract.CursorPage[T], error) {
+	if size <= 0 {
+		return nil, fmt.Errorf("cursor page size must be positive, got %d", size)
+	}
+	if len(secret) == 0 {
+		return nil, errors.New("no cursor secret specified")
+	}
+
+	s, err := Schema[T](db)
+	if err != nil {
+		return nil, err
+	}
+
+	keys, err := cursorKeys(s, sorts)
+	if err != nil {
+		return nil, err
+	}
+
+	tx := WithContext(ctx, db)
+	if where != nil {
+		tx = tx.Where(where)
+	}
+
+	var backward, inclusive bool
+	var boundary []any
+	if cursor != "" {
+		if backward, inclusive, boundary, err = decodeCursor(secret, s, keys, cursor); err != nil {
+			return nil, err
+		}
+		tx = tx.Where(keysetCondition(keys, boundary, backward, inclusive))
+	}
+
+	tx, err = Order[T](tx, keysetSorts(keys, backward))
+	if err != nil {
+		return nil, err
+	}
+
+	var results []T
+	if err := tx.Limit(size + 1).Find(&results).Error; err != nil {
+		return nil, err
+	}
+
+	more := len(results) > size
+	if more {
+		results = results[:size]
+	}
+	if backward {
+		for i, j := 0, len(results)-1; i < j; i, j = i+1, j-1 {
+			results[i], results[j] = results[j], results[i]
+		}
+	}
+
+	first, last := boundary, boundary
+	if len(results) > 0 {
+		if first, err = keysetValues(ctx, keys, &results[0]); err != nil {
+			return nil, err
+		}
+		if last, err = keysetValues(ctx, keys, &results[len(results)-1]); err != nil {
+			return nil, err
+		}
+	}
+
+	page := &contract.CursorPage[T]{Size: size, Results: results}
+
+	// The boundary row of an exclusive cursor exists on the other side of the
+	// cursor, so there is an adjacent page in the opposite direction of the cursor.
+	// An inclusive cursor is only issued by an empty page, which has nothing after it.
+	hasNext, hasPrev := more, cursor != "" && !inclusive
+	if backward {
+		hasNext, hasPrev = cursor != "" && !inclusive, more
+	}
+	// The cursors of an empty page start from the boundary row of its cursor, which
+	// the adjacent page must include.
+	empty := len(results) == 0
+	if hasNext && last != nil {
+		if page.NextCursor, err = encodeCursor(secret, s, keys, last, false, empty); err != nil {
+			return nil, err
+		}
+	}
+	if hasPrev && first != nil {
+		if page.PrevCursor, err = encodeCursor(secret, s, keys, first, true, empty); err != nil {
+			return nil, err
+		}
+	}
+
+	return page, nil
+}
+
+// cursorKeys resolves the keyset of the sorts of T.
+func cursorKeys(s *schema.Schema, sorts []contract.Sort) ([]cursorKey, error) {
+	var keys []cursorKey
+	used := make(map[string]bool)
+
+	for _, sort := range sorts {
+		field := s.LookUpField(sort.Column)
+		if field == nil || field.DBName == "" {
+			return nil, fmt.Errorf("unknown sort column (%s) of %s", sort.Column, s.Name)
+		}
+		if sort.Nulls != contract.NullsDefault {
+			return nil, fmt.Errorf("cursor pagination doesn't support nulls order of sort column (%s)", sort.Column)
+		}
+		switch sort.Direction {
+		case "", contract.DirectionAsc, contract.DirectionDesc:
+		default:
+			return nil, fmt.Errorf("unknown sort direction (%s)", sort.Direction)
+		}
+		if used[field.DBName] {
+			continue
+		}
+		keys = append(keys, cursorKey{field: field, desc: sort.Direction == contract.DirectionDesc})
+		used[field.DBName] = true
+	}
+
+	if len(s.PrimaryFields) == 0 {
+		return nil, fmt.Errorf("cursor pagination requires a primary key of %s", s.Name)
+	}
+
+	desc := len(keys) > 0 && keys[len(keys)-1].desc
+	for _, field := range s.PrimaryFields {
+		if !used[field.DBName] {
+			keys = append(keys, cursorKey{field: field, desc: desc})
+			used[field.DBName] = true
+		}
+	}
+
+	return keys, nil
+}
+
+// keysetSorts returns the sorts of the keyset, reversed for a backward cursor.
+func keysetSorts(keys []cursorKey, backward bool) []contract.Sort {
+	sorts := make([]contract.Sort, 0, len(keys))
+	for _, key := range keys {
+		if key.desc != backward {
+			sorts = append(sorts, contract.Desc(key.field.DBName))
+		} else {
+			sorts = append(sorts, contract.Asc(key.field.DBName))
+		}
+	}
+	return sorts
+}
+
+// keysetCondition matches the rows after values in the keyset order, or before
+// them for a backward cursor, and the row of values too if inclusive. When all
+// keys have the same direction it's a row value comparison which can use a
+// composite index, otherwise it's expanded to (a > ?) OR (a = ? AND b < ?) ...
+func keysetCondition(keys []cursorKey, values []any, backward bool, inclusive bool) clause.Expression {
+	columns := make([]clause.Column, len(keys))
+	greater := make([]bool, len(keys))
+	uniform := true
+	for i, key := range keys {
+		columns[i] = clause.Column{Table: clause.CurrentTable, Name: key.field.DBName}
+		greater[i] = key.desc == backward
+		uniform = uniform && greater[i] == greater[0]
+	}
+
+	if uniform {
+		op := ">"
+		if !greater[0] {
+			op = "<"
+		}
+		if inclusive {
+			op += "="
+		}
+		placeholders := strings.TrimSuffix(strings.Repeat("?, ", len(keys)), ", ")
+		vars := make([]any, 0, 2*len(keys))
+		for _, column := range columns {
+			vars = append(vars, column)
+		}
+		vars = append(vars, values...)
+		return clause.Expr{SQL: fmt.Sprintf("(%s) %s (%s)", placeholders, op, placeholders), Vars: vars}
+	}
+
+	ors := make([]clause.Expression, 0, len(keys))
+	for i := range keys {
+		ands := make([]clause.Expression, 0, i+1)
+		for j := 0; j < i; j++ {
+			ands = append(ands, clause.Eq{Column: columns[j], Value: values[j]})
+		}
+		if greater[i] {
+			ands = append(ands, clause.Gt{Column: columns[i], Value: values[i]})
+		} else {
+			ands = append(ands, clause.Lt{Column: columns[i], Value: values[i]})
+		}
+		ors = append(ors, clause.And(ands...))
+	}
+	if inclusive {
+		ands := make([]clause.Expression, 0, len(keys))
+		for i := range keys {
+			ands = append(ands, clause.Eq{Column: columns[i], Value: values[i]})
+		}
+		ors = append(ors, clause.And(ands...))
+	}
+	return clause.AndConditions{Exprs: []clause.Expression{clause.Or(ors...)}}
+}
+
+// keysetValues returns the keyset values of row, which must not be NULL.
+func keysetValues[T any](ctx context.Context, keys []cursorKey, row *T) ([]any, error) {
+	rv := reflect.Indirect(reflect.ValueOf(row).Elem())
+	values := make([]any, len(keys))
+	for i, key := range keys {
+		value, _ := key.field.ValueOf(ctx, rv)
+		if isNull(value) {
+			return nil, fmt.Errorf("cursor pagination doesn't support NULL of sort column (%s)", key.field.DBName)
+		}
+		values[i] = value
+	}
+	return values, nil
+}
+
+func isNull(value any) bool {
+	if value == nil {
+		return true
+	}
+	if rv := reflect.ValueOf(value); rv.Kind() == reflect.Ptr && rv.IsNil() {
+		return true
+	}
+	if valuer, ok := value.(driver.Valuer); ok {
+		v, err := valuer.Value()
+		return err == nil && v == nil
+	}
+	return false
+}
+
+// keysetSignature identifies the table and the keyset a cursor was issued for.
+func keysetSignature(s *schema.Schema, keys []cursorKey) string {
+	columns := make([]string, len(keys))
+	for i, key := range keys {
+		columns[i] = key.field.DBName
+		if key.desc {
+			columns[i] += " DESC"
+		}
+	}
+	return s.Table + ":" + strings.Join(columns, ",")
+}
+
+func encodeCursor(
+	secret []byte,
+	s *schema.Schema,
+	keys []cursorKey,
+	values []any,
+	backward bool,
+	inclusive bool,
+) (string, error) {
+	token := cursorToken{Backward: backward, Inclusive: inclusive, Keys: keysetSignature(s, keys)}
+	for _, value := range values {
+		encoded, err := json.Marshal(value)
+		if err != nil {
+			return "", err
+		}
+		token.Values = append(token.Values, encoded)
+	}
+
+	payload, err := json.Marshal(token)
+	if err != nil {
+		return "", err
+	}
+
+	encoding := base64.RawURLEncoding
+	return encoding.EncodeToString(payload) + "." + encoding.EncodeToString(signCursor(secret, payload)), nil
+}
+
+// decodeCursor returns whether cursor is backward and inclusive, and its boundary values.
+func decodeCursor(secret []byte, s *schema.Schema, keys []cursorKey, cursor string) (bool, bool, []any, error) {
+	encoding := base64.RawURLEncoding
+
+	encodedPayload, encodedSignature, ok := strings.Cut(cursor, ".")
+	if !ok {
+		return false, false, nil, fmt.Errorf("%w: malformed", contract.ErrInvalidCursor)
+	}
+	payload, err := encoding.DecodeString(encodedPayload)
+	if err != nil {
+		return false, false, nil, fmt.Errorf("%w: malformed", contract.ErrInvalidCursor)
+	}
+	signature, err := encoding.DecodeString(encodedSignature)
+	if err != nil || !hmac.Equal(signature, signCursor(secret, payload)) {
+		return false, false, nil, fmt.Errorf("%w: signature mismatch", contract.ErrInvalidCursor)
+	}
+
+	var token cursorToken
+	if err := json.Unmarshal(payload, &token); err != nil {
+		return false, false, nil, fmt.Errorf("%w: %s", contract.ErrInvalidCursor, err.Error())
+	}
+	if token.Keys != keysetSignature(s, keys) || len(token.Values) != len(keys) {
+		return false, false, nil, fmt.Errorf("%w: issued for other sorts (%s)", contract.ErrInvalidCursor, token.Keys)
+	}
+
+	values := make([]any, len(keys))
+	for i, key := range keys {
+		value := reflect.New(key.field.FieldType)
+		if err := json.Unmarshal(token.Values[i], value.Interface()); err != nil {
+			return false, false, nil, fmt.Errorf("%w: %s", contract.ErrInvalidCursor, err.Error())
+		}
+		if isNull(value.Elem().Interface()) {
+			return false, false, nil, fmt.Errorf("%w: NULL of sort column (%s)", contract.ErrInvalidCursor, key.field.DBName)
+		}
+		values[i] = value.Elem().Interface()
+	}
+
+	return token.Backward, token.Inclusive, values, nil
+}
+
+func signCursor(secret []byte, payload []byte) []byte {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write(payload)
+	return mac.Sum(nil)
+}
@@ -0,0 +1,200 @@
+package macro
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/raaaaaaaay86/go-persistence-extension/contract"
+	"github.com/raaaaaaaay86/go-persistence-extension/gorme/util"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/schema"
+)
+
+// ExampleCondition matches the fields of probe according to the matcher. The
+// fields are resolved when the condition is built, by the gorm schema of the probe
+// parsed with the naming strategy of the statement db, so the association fields,
+// which are not columns, are never matched. A field named by the matcher which the
+// probe doesn't have is rejected. A probe without any matched field matches all rows.
+func ExampleCondition(probe any, matcher contract.ExampleMatcher) (clause.Expression, error) {
+	v := reflect.ValueOf(probe)
+	for v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return nil, errors.New("nil example probe")
+		}
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("example probe must be a struct, got (%T)", probe)
+	}
+	return exampleExpr{probe: v, matcher: matcher}, nil
+}
+
+type exampleExpr struct {
+	probe   reflect.Value
+	matcher contract.ExampleMatcher
+}
+
+// Build implements clause.Expression.
+func (e exampleExpr) Build(builder clause.Builder) {
+	stmt, ok := builder.(*gorm.Statement)
+	if !ok {
+		builder.AddError(errors.New("example condition requires a gorm statement"))
+		return
+	}
+
+	parsed := &gorm.Statement{DB: stmt.DB}
+	if err := parsed.Parse(e.probe.Interface()); err != nil {
+		builder.AddError(err)
+		return
+	}
+
+	expr, err := exampleCondition(parsed.Schema, e.probe, e.matcher)
+	if err != nil {
+		builder.AddError(err)
+		return
+	}
+	if expr == nil {
+		builder.WriteString("1 = 1")
+		return
+	}
+	expr.Build(builder)
+}
+
+// exampleCondition returns the condition of the probe v resolved by its schema s,
+// or nil when no field is matched.
+func exampleCondition(s *schema.Schema, v reflect.Value, matcher contract.ExampleMatcher) (clause.Expression, error) {
+	fields, values, paths := util.FlattenStructPaths(v)
+
+	columns := make([]*schema.Field, len(fields))
+	names := make(map[string]int)
+	for i, field := range fields {
+		names[field.Name] = i
+		names[paths[i]] = i
+
+		column := s.FieldsByBindName[paths[i]]
+		if _, ok := s.Relationships.Relations[strings.Split(paths[i], ".")[0]]; ok || column == nil || column.DBName == "" {
+			continue
+		}
+		columns[i] = column
+		names[column.DBName] = i
+	}
+	lookup := func(refs []string) (map[int]bool, error) {
+		found := make(map[int]bool)
+		for _, ref := range refs {
+			i, ok := names[ref]
+			if !ok {
+				return nil, fmt.Errorf("unknown example field (%s) of %s", ref, v.Type())
+			}
+			found[i] = true
+		}
+		return found, nil
+	}
+
+	ignored, err := lookup(matcher.Ignored)
+	if err != nil {
+		return nil, err
+	}
+	zeros, err := lookup(matcher.Zeros)
+	if err != nil {
+		return nil, err
+	}
+	stringMatchers := make(map[int]contract.StringMatcher)
+	for ref, m := range matcher.Fields {
+		found, err := lookup([]string{ref})
+		if err != nil {
+			return nil, err
+		}
+		for i := range found {
+			stringMatchers[i] = m
+		}
+	}
+
+	var exprs []clause.Expression
+	for i := range fields {
+		if ignored[i] || columns[i] == nil {
+			continue
+		}
+
+		column := columns[i].DBName
+		value := *values[i]
+
+		switch value.Kind() {
+		case reflect.Ptr, reflect.Slice, reflect.Map, reflect.Interface:
+			if value.IsNil() {
+				switch matcher.Nulls {
+				case contract.NullIgnore:
+				case contract.NullInclude:
+					exprs = append(exprs, clause.Expr{
+						SQL:  "? IS NULL",
+						Vars: []any{clause.Column{Table: clause.CurrentTable, Name: column}},
+					})
+				default:
+					return nil, fmt.Errorf("unknown null handling (%s)", matcher.Nulls)
+				}
+				continue
+			}
+			// A set pointer is matched even if it points to a zero value.
+			if value.Kind() == reflect.Ptr {
+				value = value.Elem()
+			}
+		default:
+			if value.IsZero() && !zeros[i] {
+				continue
+			}
+		}
+
+		if value.Kind() == reflect.String {
+			m, ok := stringMatchers[i]
+			if !ok {
+				m = matcher.Strings
+			}
+			expr, err := exampleString(column, value.String(), m)
+			if err != nil {
+				return nil, err
+			}
+			exprs = append(exprs, expr)
+			continue
+		}
+
+		exprs = append(exprs, clause.Eq{
+			Column: clause.Column{Table: clause.CurrentTable, Name: column},
+			Value:  value.Interface(),
+		})
+	}
+
+	switch {
+	case len(exprs) == 0:
+		return nil, nil
+	case len(exprs) == 1:
+		return exprs[0], nil
+	}
+
+	switch matcher.Combine {
+	case "", contract.CombineAnd:
+		return clause.AndConditions{Exprs: exprs}, nil
+	case contract.CombineOr:
+		return clause.OrConditions{Exprs: exprs}, nil
+	default:
+		return nil, fmt.Errorf("unknown combine (%s)", matcher.Combine)
+	}
+}
+
+func exampleString(column string, str string, matcher contract.StringMatcher) (clause.Expression, error) {
+	match := matcher.Match
+	if match == "" {
+		match = contract.MatchExact
+	}
+
+	if match == contract.MatchExact && matcher.Case == contract.CaseSensitive {
+		return clause.Eq{Column: clause.Column{Table: clause.CurrentTable, Name: column}, Value: str}, nil
+	}
+
+	pattern, err := LikePattern(match, str)
+	if err != nil {
+		return nil, err
+	}
+	return likeExpr(column, pattern, matcher.Case)
+}
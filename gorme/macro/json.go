@@ -0,0 +1,150 @@
+package macro
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/raaaaaaaay86/go-persistence-extension/contract"
+	"gorm.io/gorm/clause"
+)
+
+var jsonOperators = map[contract.Operator]string{
+	contract.OpJSONContains:   "? @> ?::jsonb",
+	contract.OpJSONHasKey:     "jsonb_exists(?, ?)",
+	contract.OpJSONHasAnyKey:  "jsonb_exists_any(?, ?)",
+	contract.OpJSONHasAllKeys: "jsonb_exists_all(?, ?)",
+	contract.OpJSONPathExists: "jsonb_path_exists(?, ?::jsonpath, ?::jsonb)",
+}
+
+// IsJSONOperator reports whether op is one of the contract.OpJSON operators.
+func IsJSONOperator(op contract.Operator) bool {
+	_, ok := jsonOperators[op]
+	return ok
+}
+
+// JSONCondition renders a condition on the value at path of a jsonb column.
+// The jsonb functions are used instead of the ?, ?| and ?& operators which
+// conflict with the placeholders.
+func JSONCondition(column string, path []string, op contract.Operator, value any) (clause.Expression, error) {
+	if column == "" {
+		return nil, fmt.Errorf("no column specified for operator (%s)", op)
+	}
+
+	var doc any = currentColumn(column)
+	if len(path) > 0 {
+		doc = clause.Expr{SQL: "(? #> ?)", Vars: []any{doc, textArray(path)}}
+	}
+
+	switch op {
+	case contract.OpJSONContains:
+		encoded, err := jsonValue(value)
+		if err != nil {
+			return nil, err
+		}
+		return clause.Expr{SQL: jsonOperators[op], Vars: []any{doc, encoded}}, nil
+	case contract.OpJSONHasKey:
+		key, ok := value.(string)
+		if !ok {
+			return nil, fmt.Errorf("operator (%s) expects string value, got (%T)", op, value)
+		}
+		return clause.Expr{SQL: jsonOperators[op], Vars: []any{doc, key}}, nil
+	case contract.OpJSONHasAnyKey, contract.OpJSONHasAllKeys:
+		keys, ok := value.([]string)
+		if !ok {
+			return nil, fmt.Errorf("operator (%s) expects []string value, got (%T)", op, value)
+		}
+		return clause.Expr{SQL: jsonOperators[op], Vars: []any{doc, textArray(keys)}}, nil
+	case contract.OpJSONPathExists:
+		query, ok := value.(contract.JSONPathQuery)
+		if !ok {
+			return nil, fmt.Errorf("operator (%s) expects contract.JSONPathQuery value, got (%T)", op, value)
+		}
+		vars := query.Vars
+		if vars == nil {
+			vars = map[string]any{}
+		}
+		encoded, err := jsonValue(vars)
+		if err != nil {
+			return nil, err
+		}
+		return clause.Expr{SQL: jsonOperators[op], Vars: []any{doc, query.Path, encoded}}, nil
+	}
+
+	enum, err := OperatorOf(op)
+	if err != nil {
+		return nil, err
+	}
+	if len(path) == 0 {
+		return nil, fmt.Errorf("operator (%s) expects a json path of column (%s)", op, column)
+	}
+
+	text := clause.Expr{SQL: "(? #>> ?)", Vars: []any{currentColumn(column), textArray(path)}}
+	if cast := jsonCast(value); cast != "" {
+		text = clause.Expr{SQL: "(? #>> ?)::" + cast, Vars: text.Vars}
+	}
+
+	return ConditionOf(text, enum, value)
+}
+
+// textArray renders values as ARRAY[...]::text[]. gorm expands a bound slice
+// into a list, which is not accepted by the jsonb operators.
+func textArray(values []string) clause.Expr {
+	if len(values) == 0 {
+		return clause.Expr{SQL: "ARRAY[]::text[]"}
+	}
+
+	vars := make([]any, len(values))
+	for i, value := range values {
+		vars[i] = value
+	}
+	return clause.Expr{
+		SQL:  "ARRAY[" + strings.TrimSuffix(strings.Repeat("?, ", len(values)), ", ") + "]::text[]",
+		Vars: vars,
+	}
+}
+
+// jsonCast returns the SQL type the text value at a json path is casted to
+// before being compared with value. Strings are compared as text.
+func jsonCast(value any) string {
+	v := reflect.Indirect(reflect.ValueOf(value))
+	if !v.IsValid() {
+		return ""
+	}
+
+	if v.Kind() == reflect.Slice || v.Kind() == reflect.Array {
+		if v.Len() == 0 {
+			return ""
+		}
+		return jsonCast(v.Index(0).Interface())
+	}
+
+	if v.Type() == reflect.TypeOf(time.Time{}) {
+		return "timestamptz"
+	}
+
+	switch v.Kind() {
+	case reflect.Bool:
+		return "boolean"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return "numeric"
+	default:
+		return ""
+	}
+}
+
+func jsonValue(value any) (string, error) {
+	if raw, ok := value.(json.RawMessage); ok {
+		return string(raw), nil
+	}
+
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		return "", err
+	}
+	return string(encoded), nil
+}
@@ -0,0 +1,147 @@
+package macro
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+
+	"github.com/raaaaaaaay86/go-persistence-extension/contract"
+	"github.com/raaaaaaaay86/go-persistence-extension/gorme/util"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// LikePattern converts str into a LIKE pattern of the match mode. The wildcards
+// in str are escaped unless mode is contract.MatchPattern.
+func LikePattern(mode contract.MatchMode, str string) (string, error) {
+	switch mode {
+	case "", contract.MatchContains:
+		return "%" + EscapeLike(str) + "%", nil
+	case contract.MatchPrefix:
+		return EscapeLike(str) + "%", nil
+	case contract.MatchSuffix:
+		return "%" + EscapeLike(str), nil
+	case contract.MatchExact:
+		return EscapeLike(str), nil
+	case contract.MatchPattern:
+		return str, nil
+	default:
+		return "", fmt.Errorf("unknown match (%s)", mode)
+	}
+}
+
+// LikeCondition matches the non-zero string fields of entity with the options.
+// The zero value of opts binds the field values verbatim as the patterns, and
+// it returns nil when entity has no like field, so every record is matched.
+func LikeCondition(entity any, opts contract.LikeOptions) (clause.Expression, error) {
+	fields, err := util.ParseNoneZeroFields(reflect.ValueOf(entity))
+	if err != nil {
+		return nil, err
+	}
+
+	match := opts.Match
+	if match == "" {
+		match = contract.MatchPattern
+	}
+
+	var exprs []clause.Expression
+	for _, field := range fields {
+		str, ok := field.Value.(string)
+		if !ok {
+			continue
+		}
+
+		pattern, err := LikePattern(match, str)
+		if err != nil {
+			return nil, err
+		}
+
+		expr, err := likeExpr(field.ColumnName, pattern, opts.Case)
+		if err != nil {
+			return nil, err
+		}
+		exprs = append(exprs, expr)
+	}
+
+	switch {
+	case len(exprs) == 0 && opts == contract.LikeOptions{}:
+		return nil, nil
+	case len(exprs) == 0:
+		return nil, errors.New("no like field specified")
+	case len(exprs) == 1:
+		return exprs[0], nil
+	}
+
+	switch opts.Combine {
+	case "", contract.CombineAnd:
+		return clause.AndConditions{Exprs: exprs}, nil
+	case contract.CombineOr:
+		return clause.OrConditions{Exprs: exprs}, nil
+	default:
+		return nil, fmt.Errorf("unknown combine (%s)", opts.Combine)
+	}
+}
+
+func likeExpr(column string, pattern string, mode contract.CaseMode) (clause.Expression, error) {
+	col := clause.Column{Table: clause.CurrentTable, Name: column}
+	switch mode {
+	case contract.CaseSensitive:
+		return clause.Expr{SQL: "? LIKE ?", Vars: []any{col, pattern}}, nil
+	case contract.CaseInsensitive:
+		return clause.Expr{SQL: "? ILIKE ?", Vars: []any{col, pattern}}, nil
+	case contract.CaseLower:
+		return clause.Expr{SQL: "lower(?) LIKE lower(?)", Vars: []any{col, pattern}}, nil
+	default:
+		return nil, fmt.Errorf("unknown case mode (%s)", mode)
+	}
+}
+
+func LikeFind[T any](
+	ctx context.Context,
+	db *gorm.DB,
+	entity T,
+	opts contract.LikeOptions,
+	limit int,
+	sorts ...contract.Sort,
+) ([]*T, error) {
+	var results []*T
+
+	expr, err := LikeCondition(entity, opts)
+	if err != nil {
+		return results, err
+	}
+
+	db = WithContext(ctx, db)
+	if expr != nil {
+		db = db.Where(expr)
+	}
+
+	db, err = Order[T](db, sorts)
+	if err != nil {
+		return results, err
+	}
+
+	if err := db.Limit(limit).Find(&results).Error; err != nil {
+		return results, err
+	}
+
+	return results, nil
+}
+
+func LikePFind[T any](
+	ctx context.Context,
+	db *gorm.DB,
+	entity T,
+	opts contract.LikeOptions,
+	page int,
+	pageSize int,
+	sorts ...contract.Sort,
+) (*contract.Pagination[T], error) {
+	expr, err := LikeCondition(entity, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return Paginate[T](ctx, db, Where(expr), page, pageSize, sorts)
+}
@@ -10,28 +10,61 @@ import (
 	"github.com/raaaaaaaay86/go-persistence-extension/gorme/macro/operator"
 	"github.com/raaaaaaaay86/go-persistence-extension/gorme/util"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
-func CompareFind[T any, Q contract.Number](
+func CompareFind[T any, Q contract.Comparable](
 	ctx context.Context,
 	db *gorm.DB,
-	entity T,
+	entity contract.Selector,
 	value Q,
 	operator operator.Enum,
 	limit int,
+	sorts ...contract.Sort,
+) ([]*T, error) {
+	return OperatorFind[T](ctx, db, entity, operator, value, limit, sorts...)
+}
+
+func ComparePFind[T any, Q contract.Comparable](
+	ctx context.Context,
+	db *gorm.DB,
+	entity contract.Selector,
+	value Q,
+	operator operator.Enum,
+	page int,
+	pageSize int,
+	sorts ...contract.Sort,
+) (*contract.Pagination[T], error) {
+	return OperatorPFind[T](ctx, db, entity, operator, value, page, pageSize, sorts...)
+}
+
+// OperatorFind returns records whose target field matches "field operator value".
+// The target field is the non-zero field of the entity which has the same type as
+// value (or as the elements of value for IN, NOT_IN and BETWEEN). For IS_NULL and
+// IS_NOT_NULL the only non-zero field of the entity is targeted.
+func OperatorFind[T any](
+	ctx context.Context,
+	db *gorm.DB,
+	entity contract.Selector,
+	operator operator.Enum,
+	value any,
+	limit int,
+	sorts ...contract.Sort,
 ) ([]*T, error) {
-	f := fmt.Sprintf
 	var results []*T
 
-	var number Q
-	field, err := util.ParseTargetField(entity, reflect.TypeOf(number))
+	expr, err := targetCondition(entity, operator, value)
 	if err != nil {
 		return results, err
 	}
 
-	db = db.WithContext(ctx)
+	ordered, err := Order[T](WithContext(ctx, db), sorts)
+	if err != nil {
+		return results, err
+	}
 
-	tx := db.Where(f("%s %s ?", field.ColumnName, operator), value).
+	tx := ordered.
+		Where(expr).
 		Limit(limit).
 		Find(&results)
 	if tx.Error != nil {
@@ -41,87 +74,88 @@ func CompareFind[T any, Q contract.Number](
 	return results, nil
 }
 
-func ComparePFind[T any, Q contract.Number](
+// OperatorPFind is the paginated version of OperatorFind.
+func OperatorPFind[T any](
 	ctx context.Context,
 	db *gorm.DB,
-	entity T,
-	value Q,
+	entity contract.Selector,
 	operator operator.Enum,
+	value any,
 	page int,
 	pageSize int,
+	sorts ...contract.Sort,
 ) (*contract.Pagination[T], error) {
-	f := fmt.Sprintf
-	var results []T
-
-	var number Q
-	field, err := util.ParseTargetField(entity, reflect.TypeOf(number))
+	expr, err := targetCondition(entity, operator, value)
 	if err != nil {
 		return nil, err
 	}
 
-	tx := db.
-		WithContext(ctx).
-		Offset(Offset(page, pageSize)).
-		Where(f("%s %s ?", field.ColumnName, operator), value).
-		Limit(pageSize).
-		Find(&results)
-	if tx.Error != nil {
-		return nil, tx.Error
-	}
+	return Paginate[T](ctx, db, Where(expr), page, pageSize, sorts)
+}
 
-	var total int64
-	tx = db.WithContext(ctx).
-		Model(entity).
-		Where(f("%s %s ?", field.ColumnName, operator), value).
-		Count(&total)
-	if tx.Error != nil {
-		return nil, tx.Error
+func targetCondition(entity any, op operator.Enum, value any) (clause.Expression, error) {
+	var field *util.FieldInformation
+	var err error
+
+	switch op {
+	case operator.IS_NULL, operator.IS_NOT_NULL,
+		operator.ARRAY_CONTAINS, operator.ARRAY_CONTAINED_BY, operator.ARRAY_OVERLAP, operator.ANY:
+		field, err = util.ParseMarkedField(entity)
+	case operator.IN, operator.NOT_IN, operator.BETWEEN:
+		var values []any
+		if values, err = ToValues(value); err != nil {
+			return nil, err
+		}
+		if len(values) == 0 {
+			field, err = util.ParseMarkedField(entity)
+		} else {
+			field, err = util.ParseTargetField(entity, reflect.TypeOf(values[0]))
+		}
+	default:
+		if value == nil {
+			field, err = util.ParseMarkedField(entity)
+		} else {
+			field, err = util.ParseTargetField(entity, reflect.TypeOf(value))
+		}
+	}
+	if err != nil {
+		return nil, err
 	}
 
-	return contract.NewPagination(results, page, pageSize, total), nil
+	return Condition(field.ColumnName, op, value)
 }
 
 func PFindByTime[T any, Q contract.Identifier](
 	ctx context.Context,
 	db *gorm.DB,
 	operator operator.Enum,
-	entity T,
+	entity contract.Selector,
 	before time.Time,
 	page int,
 	pageSize int,
+	sorts ...contract.Sort,
 ) (*contract.Pagination[T], error) {
-	f := fmt.Sprintf
-	var results []T
-
 	field, err := util.ParseTargetField(entity, reflect.TypeOf(time.Time{}))
 	if err != nil {
 		return nil, err
 	}
 
-	if err := db.
-		Offset(Offset(page, pageSize)).
-		Limit(pageSize).
-		Where(f("%s %s ?", field.ColumnName, operator), before).
-		Find(&results).Error; err != nil {
-		return nil, err
-	}
-
-	total, err := TotalCount[T](ctx, db, page, pageSize)
-	if err != nil {
-		return nil, err
-	}
-
-	return contract.NewPagination(results, page, pageSize, total), nil
+	expr := clause.Expr{SQL: fmt.Sprintf("%s %s ?", field.ColumnName, operator), Vars: []any{before}}
+	return Paginate[T](ctx, db, Where(expr), page, pageSize, sorts)
 }
 
 func Offset(page int, pageSize int) int {
 	return (page - 1) * pageSize
 }
 
+// TotalCount counts all the records of T.
+//
+// Deprecated: it ignores the conditions of the page, use Paginate which counts
+// with the same conditions.
 func TotalCount[T any](ctx context.Context, db *gorm.DB, page int, pageSize int) (int64, error) {
 	var entity T
 	var total int64
-	if err := db.WithContext(ctx).Model(entity).Count(&total).Error; err != nil {
+	if err := WithContext(ctx, db).Model(entity).Count(&total).Error; err != nil {
 		return 0, err
 	}
 	return total, nil
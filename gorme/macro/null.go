@@ -0,0 +1,85 @@
+package macro
+
+import (
+	"context"
+	"reflect"
+
+	"github.com/raaaaaaaay86/go-persistence-extension/contract"
+	"github.com/raaaaaaaay86/go-persistence-extension/gorme/util"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// NullCondition matches the marked field of entity with IS NULL, or IS NOT NULL
+// when null is false. The field is the only non-zero field of entity, such as
+// mark.TargetPtr, mark.TargetNullString or mark.TargetGormDeleteAt.
+//
+// The soft delete scope of gorm would hide every record with a non-null
+// gorm.DeletedAt, so unscoped reports whether the query must be Unscoped.
+func NullCondition(entity any, null bool) (expr clause.Expression, unscoped bool, err error) {
+	field, err := util.ParseMarkedField(entity)
+	if err != nil {
+		return nil, false, err
+	}
+
+	sql := "? IS NULL"
+	if !null {
+		sql = "? IS NOT NULL"
+	}
+
+	expr = clause.Expr{SQL: sql, Vars: []any{clause.Column{Table: clause.CurrentTable, Name: field.ColumnName}}}
+	return expr, field.ReflectType == reflect.TypeOf(gorm.DeletedAt{}), nil
+}
+
+func whereNull(db *gorm.DB, entity any, null bool) (*gorm.DB, error) {
+	expr, unscoped, err := NullCondition(entity, null)
+	if err != nil {
+		return nil, err
+	}
+	if unscoped {
+		db = db.Unscoped()
+	}
+	return db.Where(expr), nil
+}
+
+func NullFind[T any](
+	ctx context.Context,
+	db *gorm.DB,
+	entity contract.Selector,
+	null bool,
+	limit int,
+	sorts ...contract.Sort,
+) ([]*T, error) {
+	var results []*T
+
+	where, err := whereNull(WithContext(ctx, db), entity, null)
+	if err != nil {
+		return results, err
+	}
+
+	ordered, err := Order[T](where, sorts)
+	if err != nil {
+		return results, err
+	}
+
+	if err := ordered.Limit(limit).Find(&results).Error; err != nil {
+		return results, err
+	}
+
+	return results, nil
+}
+
+func NullPFind[T any](
+	ctx context.Context,
+	db *gorm.DB,
+	entity contract.Selector,
+	null bool,
+	page int,
+	pageSize int,
+	sorts ...contract.Sort,
+) (*contract.Pagination[T], error) {
+	where := func(db *gorm.DB) (*gorm.DB, error) {
+		return whereNull(db, entity, null)
+	}
+	return Paginate[T](ctx, db, where, page, pageSize, sorts)
+}
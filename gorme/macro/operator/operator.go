@@ -3,9 +3,25 @@ package operator
 type Enum string
 
 const (
-	EQ  Enum = "="
-	GT  Enum = ">"
-	GTE Enum = ">="
-	LT  Enum = "<"
-	LTE Enum = "<="
-)
\ No newline at end of file
+	EQ          Enum = "="
+	NEQ         Enum = "<>"
+	GT          Enum = ">"
+	GTE         Enum = ">="
+	LT          Enum = "<"
+	LTE         Enum = "<="
+	IN          Enum = "IN"
+	NOT_IN      Enum = "NOT IN"
+	IS_NULL     Enum = "IS NULL"
+	IS_NOT_NULL Enum = "IS NOT NULL"
+	BETWEEN     Enum = "BETWEEN"
+	LIKE        Enum = "LIKE"
+	ILIKE       Enum = "ILIKE"
+	PREFIX      Enum = "PREFIX"
+	SUFFIX      Enum = "SUFFIX"
+	CONTAINS    Enum = "CONTAINS"
+
+	ARRAY_CONTAINS     Enum = "@>"
+	ARRAY_CONTAINED_BY Enum = "<@"
+	ARRAY_OVERLAP      Enum = "&&"
+	ANY                Enum = "ANY"
+)
@@ -0,0 +1,88 @@
+package macro
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/raaaaaaaay86/go-persistence-extension/contract"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/schema"
+)
+
+// Order applies the sorts to db as an ORDER BY clause followed by the primary keys
+// of T as tie-breaker. Every sort column must be a column name or a field name of T.
+// The leading expressions are placed before the sorts, they must include their
+// own direction.
+func Order[T any](db *gorm.DB, sorts []contract.Sort, leading ...clause.Expression) (*gorm.DB, error) {
+	s, err := Schema[T](db)
+	if err != nil {
+		return nil, err
+	}
+
+	var sqls []string
+	var vars []any
+	ordered := make(map[string]bool)
+
+	for _, expr := range leading {
+		sqls = append(sqls, "?")
+		vars = append(vars, expr)
+	}
+
+	for _, sort := range sorts {
+		field := s.LookUpField(sort.Column)
+		if field == nil || field.DBName == "" {
+			return nil, fmt.Errorf("unknown sort column (%s) of %s", sort.Column, s.Name)
+		}
+		if ordered[field.DBName] {
+			continue
+		}
+
+		sql := "?"
+		switch sort.Direction {
+		case "", contract.DirectionAsc:
+		case contract.DirectionDesc:
+			sql += " DESC"
+		default:
+			return nil, fmt.Errorf("unknown sort direction (%s)", sort.Direction)
+		}
+		switch sort.Nulls {
+		case contract.NullsDefault:
+		case contract.NullsFirst, contract.NullsLast:
+			sql += " " + string(sort.Nulls)
+		default:
+			return nil, fmt.Errorf("unknown sort nulls order (%s)", sort.Nulls)
+		}
+
+		sqls = append(sqls, sql)
+		vars = append(vars, clause.Column{Table: clause.CurrentTable, Name: field.DBName})
+		ordered[field.DBName] = true
+	}
+
+	for _, field := range s.PrimaryFields {
+		if ordered[field.DBName] {
+			continue
+		}
+		sqls = append(sqls, "?")
+		vars = append(vars, clause.Column{Table: clause.CurrentTable, Name: field.DBName})
+		ordered[field.DBName] = true
+	}
+
+	if len(sqls) == 0 {
+		return db, nil
+	}
+
+	return db.Clauses(clause.OrderBy{
+		Expression: clause.Expr{SQL: strings.Join(sqls, ", "), Vars: vars, WithoutParentheses: true},
+	}), nil
+}
+
+// Schema returns the parsed gorm schema of T.
+func Schema[T any](db *gorm.DB) (*schema.Schema, error) {
+	var entity T
+	stmt := &gorm.Statement{DB: db}
+	if err := stmt.Parse(&entity); err != nil {
+		return nil, err
+	}
+	return stmt.Schema, nil
+}
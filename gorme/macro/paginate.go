@@ -0,0 +1,210 @@
+package macro
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/raaaaaaaay86/go-persistence-extension/contract"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type countModeKey struct{}
+
+// ContextWithCountMode returns a copy of ctx making the paginated queries count
+// their total by mode.
+func ContextWithCountMode(ctx context.Context, mode contract.CountMode) context.Context {
+	return context.WithValue(ctx, countModeKey{}, mode)
+}
+
+// CountModeOf returns the count mode carried by ctx, CountExact by default.
+func CountModeOf(ctx context.Context) contract.CountMode {
+	mode, _ := ctx.Value(countModeKey{}).(contract.CountMode)
+	return mode
+}
+
+// Paginate returns the page of the records of T matched by where, which applies
+// the conditions to the db it's given. The records are ordered by the leading
+// expressions and sorts, and are counted with the same conditions.
+func Paginate[T any](
+	ctx context.Context,
+	db *gorm.DB,
+	where func(db *gorm.DB) (*gorm.DB, error),
+	page int,
+	pageSize int,
+	sorts []contract.Sort,
+	leading ...clause.Expression,
+) (*contract.Pagination[T], error) {
+	find := func(ctx context.Context, offset int, limit int) ([]T, error) {
+		var results []T
+
+		query, err := where(WithContext(ctx, db))
+		if err != nil {
+			return nil, err
+		}
+
+		if query, err = Order[T](query, sorts, leading...); err != nil {
+			return nil, err
+		}
+
+		if err := query.Offset(offset).Limit(limit).Find(&results).Error; err != nil {
+			return nil, err
+		}
+
+		return results, nil
+	}
+
+	count := func(ctx context.Context, mode contract.CountMode) (int64, error) {
+		var model T
+		query, err := where(WithContext(ctx, db).Model(&model))
+		if err != nil {
+			return 0, err
+		}
+		return Count(query, mode)
+	}
+
+	return PaginateFunc(ctx, db, page, pageSize, find, count)
+}
+
+// Where returns the where function of Paginate applying expr, or nothing if it's nil.
+func Where(expr clause.Expression) func(db *gorm.DB) (*gorm.DB, error) {
+	return func(db *gorm.DB) (*gorm.DB, error) {
+		if expr == nil {
+			return db, nil
+		}
+		return db.Where(expr), nil
+	}
+}
+
+// PaginateFunc returns the page of the results of find, which queries limit
+// results after offset, and counts them by count per the count mode of ctx. The
+// queries must be run on WithContext of the ctx they are given.
+//
+// find is asked for one more result than pageSize to know whether there is a next
+// page without counting. An exact count runs in the same REPEATABLE READ snapshot
+// as find, unless ctx already carries a transaction. The count is skipped when
+// the page is the last one, as the total is known.
+func PaginateFunc[R any](
+	ctx context.Context,
+	db *gorm.DB,
+	page int,
+	pageSize int,
+	find func(ctx context.Context, offset int, limit int) ([]R, error),
+	count func(ctx context.Context, mode contract.CountMode) (int64, error),
+) (*contract.Pagination[R], error) {
+	if page < 1 {
+		return nil, fmt.Errorf("page must be positive, got %d", page)
+	}
+	if pageSize < 1 {
+		return nil, fmt.Errorf("page size must be positive, got %d", pageSize)
+	}
+
+	mode := CountModeOf(ctx)
+	switch mode {
+	case contract.CountExact, contract.CountNone, contract.CountEstimate:
+	default:
+		return nil, fmt.Errorf("unknown count mode (%s)", mode)
+	}
+
+	offset := Offset(page, pageSize)
+	var results []R
+	var total int64
+	var more bool
+
+	run := func(ctx context.Context) error {
+		var err error
+		if results, err = find(ctx, offset, pageSize+1); err != nil {
+			return err
+		}
+
+		more = len(results) > pageSize
+		if more {
+			results = results[:pageSize]
+		}
+
+		switch {
+		case mode == contract.CountNone:
+			total = -1
+		case !more && (len(results) > 0 || offset == 0):
+			total = int64(offset + len(results))
+		default:
+			if total, err = count(ctx, mode); err != nil {
+				return err
+			}
+			// An estimate can't be less than the results already seen.
+			if seen := int64(offset + len(results)); more && total <= seen {
+				total = seen + 1
+			}
+		}
+		return nil
+	}
+
+	var err error
+	if mode == contract.CountExact && !inTx(ctx) {
+		err = WithContext(ctx, db).Transaction(func(tx *gorm.DB) error {
+			return run(ContextWithTx(ctx, tx))
+		}, &sql.TxOptions{Isolation: sql.LevelRepeatableRead, ReadOnly: true})
+	} else {
+		err = run(ctx)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	p := contract.NewPagination(results, page, pageSize, total)
+	p.Count = mode
+	p.More = more
+	return p, nil
+}
+
+// Count counts the records of query, which has a model, by mode.
+func Count(query *gorm.DB, mode contract.CountMode) (int64, error) {
+	if mode != contract.CountEstimate {
+		var total int64
+		if err := query.Count(&total).Error; err != nil {
+			return 0, err
+		}
+		return total, nil
+	}
+
+	stmt := query.Session(&gorm.Session{DryRun: true}).Find(&[]map[string]any{}).Statement
+	if stmt.Error != nil {
+		return 0, stmt.Error
+	}
+	return explainRows(query, stmt.SQL.String(), stmt.Vars...)
+}
+
+// EstimateRawCount returns the planner estimate of the rows of the raw sql.
+func EstimateRawCount(db *gorm.DB, sql string, args ...any) (int64, error) {
+	stmt := db.Session(&gorm.Session{DryRun: true}).Raw(sql, args...).Statement
+	if stmt.Error != nil {
+		return 0, stmt.Error
+	}
+	return explainRows(db, stmt.SQL.String(), stmt.Vars...)
+}
+
+// explainRows returns the "Plan Rows" of the top plan node of the built sql,
+// which PostgreSQL derives from pg_class.reltuples and the column statistics.
+func explainRows(db *gorm.DB, sql string, vars ...any) (int64, error) {
+	var plan string
+	row := db.Statement.ConnPool.QueryRowContext(db.Statement.Context, "EXPLAIN (FORMAT JSON) "+sql, vars...)
+	if err := row.Scan(&plan); err != nil {
+		return 0, err
+	}
+
+	var plans []struct {
+		Plan struct {
+			Rows float64 `json:"Plan Rows"`
+		}
+	}
+	if err := json.Unmarshal([]byte(plan), &plans); err != nil {
+		return 0, err
+	}
+	if len(plans) == 0 {
+		return 0, errors.New("no plan explained")
+	}
+	return int64(plans[0].Plan.Rows), nil
+}
@@ -0,0 +1,135 @@
+package macro
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/raaaaaaaay86/go-persistence-extension/contract"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ProjectFind is like FindBySpec but selects only the columns of D and scans the
+// results into D. A field of D matches a column of T by column name first, then
+// by field name.
+func ProjectFind[T any, D any](
+	ctx context.Context,
+	db *gorm.DB,
+	spec contract.Spec,
+	opts contract.FindOptions,
+) ([]*D, error) {
+	var results []*D
+
+	db, err := projectionQuery[T, D](ctx, db, spec)
+	if err != nil {
+		return results, err
+	}
+
+	if db, err = Order[T](db, opts.Sort); err != nil {
+		return results, err
+	}
+
+	if opts.Limit > 0 {
+		db = db.Limit(opts.Limit)
+	}
+
+	if err := db.Find(&results).Error; err != nil {
+		return results, err
+	}
+
+	return results, nil
+}
+
+// ProjectPFind is the paginated version of ProjectFind.
+func ProjectPFind[T any, D any](
+	ctx context.Context,
+	db *gorm.DB,
+	spec contract.Spec,
+	page int,
+	pageSize int,
+	sorts ...contract.Sort,
+) (*contract.Pagination[D], error) {
+	find := func(ctx context.Context, offset int, limit int) ([]D, error) {
+		var results []D
+
+		query, err := projectionQuery[T, D](ctx, db, spec)
+		if err != nil {
+			return nil, err
+		}
+
+		if query, err = Order[T](query, sorts); err != nil {
+			return nil, err
+		}
+
+		if err := query.Offset(offset).Limit(limit).Find(&results).Error; err != nil {
+			return nil, err
+		}
+
+		return results, nil
+	}
+
+	count := func(ctx context.Context, mode contract.CountMode) (int64, error) {
+		var entity T
+		query, err := WhereSpec(WithContext(ctx, db).Model(&entity), spec)
+		if err != nil {
+			return 0, err
+		}
+		return Count(query, mode)
+	}
+
+	return PaginateFunc(ctx, db, page, pageSize, find, count)
+}
+
+func projectionQuery[T any, D any](ctx context.Context, db *gorm.DB, spec contract.Spec) (*gorm.DB, error) {
+	columns, err := ProjectionColumns[T, D](db)
+	if err != nil {
+		return nil, err
+	}
+
+	var entity T
+	tx := WithContext(ctx, db).Model(&entity)
+	// Associations of T can not be preloaded into D.
+	tx.Statement.Preloads = nil
+
+	tx, err = WhereSpec(tx, spec)
+	if err != nil {
+		return nil, err
+	}
+
+	return tx.Clauses(clause.Select{Columns: columns}), nil
+}
+
+// ProjectionColumns returns the columns of T selected by the fields of D.
+func ProjectionColumns[T any, D any](db *gorm.DB) ([]clause.Column, error) {
+	source, err := Schema[T](db)
+	if err != nil {
+		return nil, err
+	}
+
+	target, err := Schema[D](db)
+	if err != nil {
+		return nil, err
+	}
+
+	var columns []clause.Column
+	for _, field := range target.Fields {
+		if field.DBName == "" {
+			continue
+		}
+
+		if f, ok := source.FieldsByDBName[field.DBName]; ok {
+			columns = append(columns, clause.Column{Table: clause.CurrentTable, Name: f.DBName})
+			continue
+		}
+
+		if f, ok := source.FieldsByName[field.Name]; ok && f.DBName != "" {
+			columns = append(columns, clause.Column{Table: clause.CurrentTable, Name: f.DBName, Alias: field.DBName})
+		}
+	}
+
+	if len(columns) == 0 {
+		return nil, fmt.Errorf("no field of %s matches a column of %s", target.ModelType, source.ModelType)
+	}
+
+	return columns, nil
+}
@@ -0,0 +1,69 @@
+package macro
+
+import (
+	"database/sql/driver"
+	"reflect"
+	"sort"
+
+	"github.com/raaaaaaaay86/go-persistence-extension/contract"
+	"github.com/raaaaaaaay86/go-persistence-extension/gorme/macro/operator"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// BuildQueryMap translates a contract.QueryMap into a gorm clause expression.
+// A value which is a contract.Spec is translated by BuildSpec and its key only
+// identifies the entry, any other value is matched by equality on the key column.
+// A nil value, or a driver.Valuer of nil, is matched by IS NULL.
+func BuildQueryMap(query contract.QueryMap) (clause.Expression, error) {
+	keys := make([]string, 0, len(query))
+	for key := range query {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	exprs := make([]clause.Expression, 0, len(keys))
+	for _, key := range keys {
+		expr, err := queryMapEntry(key, query[key])
+		if err != nil {
+			return nil, err
+		}
+		if expr != nil {
+			exprs = append(exprs, expr)
+		}
+	}
+
+	if len(exprs) <= 1 {
+		return firstOrNil(exprs), nil
+	}
+	return clause.AndConditions{Exprs: exprs}, nil
+}
+
+func queryMapEntry(key string, value any) (clause.Expression, error) {
+	switch v := value.(type) {
+	case contract.Spec:
+		return BuildSpec(v)
+	case driver.Valuer, []byte:
+		return clause.Eq{Column: clause.Column{Name: key}, Value: value}, nil
+	}
+
+	// Keep the gorm map semantic, a slice value is matched by IN.
+	kind := reflect.Indirect(reflect.ValueOf(value)).Kind()
+	if kind == reflect.Slice || kind == reflect.Array {
+		return Condition(key, operator.IN, reflect.Indirect(reflect.ValueOf(value)).Interface())
+	}
+
+	return clause.Eq{Column: clause.Column{Name: key}, Value: value}, nil
+}
+
+// WhereQueryMap applies the translated QueryMap to db as a WHERE condition.
+func WhereQueryMap(db *gorm.DB, query contract.QueryMap) (*gorm.DB, error) {
+	expr, err := BuildQueryMap(query)
+	if err != nil {
+		return nil, err
+	}
+	if expr == nil {
+		return db, nil
+	}
+	return db.Where(expr), nil
+}
@@ -0,0 +1,86 @@
+package macro
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/raaaaaaaay86/go-persistence-extension/contract"
+	"github.com/raaaaaaaay86/go-persistence-extension/gorme/util"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Search runs a full-text search over the non-zero string fields of entity and
+// orders the results by ts_rank.
+//
+// - configuration: the text search configuration, e.g. simple or english.
+// - tsquery: the function parsing the query, one of plainto_tsquery, websearch_to_tsquery,
+// phraseto_tsquery and to_tsquery.
+// - vectorColumn: a stored tsvector column. If it's not empty, it's searched instead of the entity fields.
+func Search[T any](
+	ctx context.Context,
+	db *gorm.DB,
+	entity T,
+	query string,
+	configuration string,
+	tsquery string,
+	vectorColumn string,
+	page int,
+	pageSize int,
+) (*contract.Pagination[T], error) {
+	if !tsqueryFunctions[tsquery] {
+		return nil, fmt.Errorf("unknown search mode (%s)", tsquery)
+	}
+
+	vector, err := searchVector(entity, configuration, vectorColumn)
+	if err != nil {
+		return nil, err
+	}
+
+	tsq := clause.Expr{SQL: fmt.Sprintf("%s(?::regconfig, ?)", tsquery), Vars: []any{configuration, query}}
+	match := clause.Expr{SQL: "? @@ ?", Vars: []any{vector, tsq}}
+	rank := clause.Expr{SQL: "ts_rank(?, ?) DESC", Vars: []any{vector, tsq}}
+
+	return Paginate[T](ctx, db, Where(match), page, pageSize, nil, rank)
+}
+
+// tsqueryFunctions are the functions Search accepts, as tsquery is written into the SQL.
+var tsqueryFunctions = map[string]bool{
+	"plainto_tsquery":      true,
+	"websearch_to_tsquery": true,
+	"phraseto_tsquery":     true,
+	"to_tsquery":           true,
+}
+
+func searchVector(entity any, configuration string, vectorColumn string) (clause.Expression, error) {
+	if vectorColumn != "" {
+		return clause.Expr{SQL: "?", Vars: []any{clause.Column{Table: clause.CurrentTable, Name: vectorColumn}}}, nil
+	}
+
+	fields, err := util.ParseNoneZeroFields(reflect.ValueOf(entity))
+	if err != nil {
+		return nil, err
+	}
+
+	var sqls []string
+	vars := []any{configuration}
+	for _, field := range fields {
+		if _, ok := field.Value.(string); !ok {
+			continue
+		}
+		sqls = append(sqls, "coalesce(?, '')")
+		vars = append(vars, clause.Column{Table: clause.CurrentTable, Name: field.ColumnName})
+	}
+
+	if len(sqls) == 0 {
+		return nil, errors.New("no search field specified")
+	}
+
+	return clause.Expr{
+		SQL:  fmt.Sprintf("to_tsvector(?::regconfig, %s)", strings.Join(sqls, " || ' ' || ")),
+		Vars: vars,
+	}, nil
+}
@@ -0,0 +1,255 @@
+package macro
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/raaaaaaaay86/go-persistence-extension/contract"
+	"github.com/raaaaaaaay86/go-persistence-extension/gorme/macro/operator"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// BuildSpec translates a contract.Spec into a gorm clause expression.
+// A nil Spec or an empty And yields a nil expression, which means no condition,
+// while an empty Or or the Not of no condition matches no rows.
+func BuildSpec(spec contract.Spec) (clause.Expression, error) {
+	switch s := spec.(type) {
+	case nil:
+		return nil, nil
+	case contract.Predicate:
+		return buildPredicate(s)
+	case contract.JSONPredicate:
+		return JSONCondition(s.Column, s.Path, s.Operator, s.Value)
+	case contract.ExamplePredicate:
+		return ExampleCondition(s.Probe, s.Matcher)
+	case contract.AssociationPredicate:
+		return AssociationCondition(s.Relation, s.Spec)
+	case contract.Conjunction:
+		exprs, err := buildSpecs(s.Specs)
+		if err != nil {
+			return nil, err
+		}
+		if len(exprs) <= 1 {
+			return firstOrNil(exprs), nil
+		}
+		return clause.AndConditions{Exprs: exprs}, nil
+	case contract.Disjunction:
+		exprs := make([]clause.Expression, 0, len(s.Specs))
+		for _, spec := range s.Specs {
+			expr, err := BuildSpec(spec)
+			if err != nil {
+				return nil, err
+			}
+			// A child without condition matches all rows, and so does the Or.
+			if expr == nil {
+				return nil, nil
+			}
+			exprs = append(exprs, expr)
+		}
+		switch len(exprs) {
+		case 0:
+			return clause.Expr{SQL: "1 = 0"}, nil
+		case 1:
+			return exprs[0], nil
+		}
+		return clause.OrConditions{Exprs: exprs}, nil
+	case contract.Negation:
+		expr, err := BuildSpec(s.Spec)
+		if err != nil {
+			return nil, err
+		}
+		if expr == nil {
+			return clause.Expr{SQL: "1 = 0"}, nil
+		}
+		return clause.Expr{SQL: "NOT (?)", Vars: []any{expr}}, nil
+	default:
+		return nil, fmt.Errorf("unsupported spec type (%T)", spec)
+	}
+}
+
+func buildSpecs(specs []contract.Spec) ([]clause.Expression, error) {
+	var exprs []clause.Expression
+	for _, spec := range specs {
+		expr, err := BuildSpec(spec)
+		if err != nil {
+			return nil, err
+		}
+		if expr != nil {
+			exprs = append(exprs, expr)
+		}
+	}
+	return exprs, nil
+}
+
+func firstOrNil(exprs []clause.Expression) clause.Expression {
+	if len(exprs) == 0 {
+		return nil
+	}
+	return exprs[0]
+}
+
+var specOperators = map[contract.Operator]operator.Enum{
+	contract.OpEq:        operator.EQ,
+	contract.OpNeq:       operator.NEQ,
+	contract.OpGt:        operator.GT,
+	contract.OpGte:       operator.GTE,
+	contract.OpLt:        operator.LT,
+	contract.OpLte:       operator.LTE,
+	contract.OpIn:        operator.IN,
+	contract.OpNotIn:     operator.NOT_IN,
+	contract.OpBetween:   operator.BETWEEN,
+	contract.OpLike:      operator.LIKE,
+	contract.OpILike:     operator.ILIKE,
+	contract.OpPrefix:    operator.PREFIX,
+	contract.OpSuffix:    operator.SUFFIX,
+	contract.OpContains:  operator.CONTAINS,
+	contract.OpIsNull:    operator.IS_NULL,
+	contract.OpIsNotNull: operator.IS_NOT_NULL,
+
+	contract.OpArrayContains:    operator.ARRAY_CONTAINS,
+	contract.OpArrayContainedBy: operator.ARRAY_CONTAINED_BY,
+	contract.OpArrayOverlap:     operator.ARRAY_OVERLAP,
+	contract.OpArrayAny:         operator.ANY,
+}
+
+// OperatorOf returns the SQL operator of a contract.Operator.
+func OperatorOf(op contract.Operator) (operator.Enum, error) {
+	enum, ok := specOperators[op]
+	if !ok {
+		return "", fmt.Errorf("unsupported operator (%s)", op)
+	}
+	return enum, nil
+}
+
+func buildPredicate(p contract.Predicate) (clause.Expression, error) {
+	if p.Column == "" {
+		return nil, fmt.Errorf("no column specified for operator (%s)", p.Operator)
+	}
+
+	op, err := OperatorOf(p.Operator)
+	if err != nil {
+		return nil, err
+	}
+
+	return ConditionOf(currentColumn(p.Column), op, p.Value)
+}
+
+// WhereSpec applies the translated Spec to db as a WHERE condition.
+func WhereSpec(db *gorm.DB, spec contract.Spec) (*gorm.DB, error) {
+	expr, err := BuildSpec(spec)
+	if err != nil {
+		return nil, err
+	}
+	if expr == nil {
+		return db, nil
+	}
+	return db.Where(expr), nil
+}
+
+func FindBySpec[T any](
+	ctx context.Context,
+	db *gorm.DB,
+	spec contract.Spec,
+	opts contract.FindOptions,
+) ([]*T, error) {
+	var results []*T
+
+	db, err := WhereSpec(WithContext(ctx, db), spec)
+	if err != nil {
+		return results, err
+	}
+
+	if db, err = Order[T](db, opts.Sort); err != nil {
+		return results, err
+	}
+
+	if opts.Limit > 0 {
+		db = db.Limit(opts.Limit)
+	}
+
+	if err := db.Find(&results).Error; err != nil {
+		return results, err
+	}
+
+	return results, nil
+}
+
+func PFindBySpec[T any](
+	ctx context.Context,
+	db *gorm.DB,
+	spec contract.Spec,
+	page int,
+	pageSize int,
+	sorts ...contract.Sort,
+) (*contract.Pagination[T], error) {
+	where := func(db *gorm.DB) (*gorm.DB, error) {
+		return WhereSpec(db, spec)
+	}
+	return Paginate[T](ctx, db, where, page, pageSize, sorts)
+}
+
+// GetBySpec returns the first record of T matched by the spec in the order of sorts.
+// It returns gorm.ErrRecordNotFound if there is no matched record.
+func GetBySpec[T any](ctx context.Context, db *gorm.DB, spec contract.Spec, sorts ...contract.Sort) (*T, error) {
+	var result T
+
+	db, err := WhereSpec(WithContext(ctx, db), spec)
+	if err != nil {
+		return nil, err
+	}
+
+	if db, err = Order[T](db, sorts); err != nil {
+		return nil, err
+	}
+
+	if err := db.Take(&result).Error; err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+// CountBySpec counts the records of T matched by the spec.
+func CountBySpec[T any](ctx context.Context, db *gorm.DB, spec contract.Spec) (int64, error) {
+	var entity T
+	var total int64
+
+	db, err := WhereSpec(WithContext(ctx, db).Model(&entity), spec)
+	if err != nil {
+		return 0, err
+	}
+
+	if err := db.Count(&total).Error; err != nil {
+		return 0, err
+	}
+
+	return total, nil
+}
+
+// ExistsBySpec reports whether any record of T is matched by the spec.
+func ExistsBySpec[T any](ctx context.Context, db *gorm.DB, spec contract.Spec) (bool, error) {
+	expr, err := BuildSpec(spec)
+	if err != nil {
+		return false, err
+	}
+	return Exists[T](ctx, db, expr)
+}
+
+// DeleteBySpec deletes the records of T matched by the spec and returns the
+// affected rows. A nil spec is rejected by gorm, which refuses a global delete.
+func DeleteBySpec[T any](ctx context.Context, db *gorm.DB, spec contract.Spec) (int64, error) {
+	var entity T
+
+	db, err := WhereSpec(WithContext(ctx, db), spec)
+	if err != nil {
+		return 0, err
+	}
+
+	tx := db.Delete(&entity)
+	if tx.Error != nil {
+		return 0, tx.Error
+	}
+
+	return tx.RowsAffected, nil
+}
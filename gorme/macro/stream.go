@@ -0,0 +1,155 @@
+package macro
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/raaaaaaaay86/go-persistence-extension/contract"
+	"gorm.io/gorm"
+)
+
+// FindEach calls fn with each record of T matched by the spec in the order of
+// sorts. The records are scanned one by one from the rows of a single query, so
+// they are never all held in memory. The associations of T are not preloaded.
+//
+// The connection of the query is held until the iteration ends, so fn must not
+// query the transaction carried by ctx, if any. It stops at the first error of fn.
+func FindEach[T any](
+	ctx context.Context,
+	db *gorm.DB,
+	spec contract.Spec,
+	fn func(*T) error,
+	sorts ...contract.Sort,
+) error {
+	var model T
+
+	query, err := WhereSpec(WithContext(ctx, db).Model(&model), spec)
+	if err != nil {
+		return err
+	}
+
+	if query, err = Order[T](query, sorts); err != nil {
+		return err
+	}
+
+	rows, err := query.Rows()
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var result T
+		if err := query.ScanRows(rows, &result); err != nil {
+			return err
+		}
+		if err := fn(&result); err != nil {
+			return err
+		}
+	}
+
+	return rows.Err()
+}
+
+// FindInBatches calls fn with the records of T matched by the spec, batchSize
+// records at a time in the order of sorts. Each batch is a query starting after
+// the keyset of the previous batch, like CursorFind, so the batches neither skip
+// nor repeat records when records are inserted or deleted meanwhile, and the
+// sort columns must not be NULL. The associations of T are preloaded by each batch.
+func FindInBatches[T any](
+	ctx context.Context,
+	db *gorm.DB,
+	spec contract.Spec,
+	batchSize int,
+	fn func([]*T) error,
+	sorts ...contract.Sort,
+) error {
+	if batchSize <= 0 {
+		return fmt.Errorf("batch size must be positive, got %d", batchSize)
+	}
+
+	s, err := Schema[T](db)
+	if err != nil {
+		return err
+	}
+
+	keys, err := cursorKeys(s, sorts)
+	if err != nil {
+		return err
+	}
+
+	var boundary []any
+	for {
+		var batch []*T
+
+		query, err := WhereSpec(WithContext(ctx, db), spec)
+		if err != nil {
+			return err
+		}
+		if boundary != nil {
+			query = query.Where(keysetCondition(keys, boundary, false, false))
+		}
+		if query, err = Order[T](query, keysetSorts(keys, false)); err != nil {
+			return err
+		}
+
+		if err := query.Limit(batchSize).Find(&batch).Error; err != nil {
+			return err
+		}
+		if len(batch) == 0 {
+			return nil
+		}
+
+		if err := fn(batch); err != nil {
+			return err
+		}
+		if len(batch) < batchSize {
+			return nil
+		}
+
+		if boundary, err = keysetValues(ctx, keys, batch[len(batch)-1]); err != nil {
+			return err
+		}
+	}
+}
+
+// FindStream sends each record of T matched by the spec to the returned record
+// channel in the order of sorts, as FindEach does. buffer is the capacity of the
+// record channel. The record channel is closed at the end of the iteration, then
+// the error channel receives the error of the iteration, if any, and is closed.
+//
+// The iteration stops with ctx.Err() when ctx is done, so a receiver which stops
+// before the end must cancel ctx to release the query.
+func FindStream[T any](
+	ctx context.Context,
+	db *gorm.DB,
+	spec contract.Spec,
+	buffer int,
+	sorts ...contract.Sort,
+) (<-chan *T, <-chan error) {
+	results := make(chan *T, max(buffer, 0))
+	errs := make(chan error, 1)
+
+	go func() {
+		defer close(errs)
+
+		err := FindEach(ctx, db, spec, func(result *T) error {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+			select {
+			case results <- result:
+				return nil
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		}, sorts...)
+		close(results)
+
+		if err != nil {
+			errs <- err
+		}
+	}()
+
+	return results, errs
+}
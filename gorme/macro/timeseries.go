@@ -0,0 +1,156 @@
+package macro
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/raaaaaaaay86/go-persistence-extension/contract"
+	"github.com/raaaaaaaay86/go-persistence-extension/gorme/util"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// TimeSeries groups the records of T matched by the query into buckets of the
+// time field of entity and computes the aggregates of each bucket. The time field
+// is the non-zero time.Time field of entity, e.g. mark.TargetTime. The buckets are
+// truncated by date_trunc in the location of opts and ordered by their start. The
+// empty buckets are generated by generate_series when opts.Fill is set.
+//
+// Without aggregates, the rows of each bucket are counted.
+//
+//	SELECT "series"."bucket", "data"."v0" FROM (
+//		SELECT generate_series(...) AT TIME ZONE 'Asia/Taipei' AS bucket
+//	) AS series LEFT JOIN (
+//		SELECT date_trunc('day', "users"."created_at"::timestamptz, 'Asia/Taipei') AS bucket, COUNT(*) AS v0
+//		FROM "users" WHERE ... GROUP BY 1
+//	) AS data ON "data"."bucket" = "series"."bucket" ORDER BY "series"."bucket"
+func TimeSeries[T any](
+	ctx context.Context,
+	db *gorm.DB,
+	entity contract.Selector,
+	opts contract.BucketOptions,
+	query contract.QueryMap,
+	aggregates ...contract.Aggregate,
+) ([]contract.Bucket, error) {
+	field, err := util.ParseTargetField(entity, reflect.TypeOf(time.Time{}))
+	if err != nil {
+		return nil, err
+	}
+
+	switch opts.Interval {
+	case contract.BucketHour, contract.BucketDay, contract.BucketWeek, contract.BucketMonth:
+	case "":
+		return nil, errors.New("no bucket interval specified")
+	default:
+		return nil, fmt.Errorf("unknown bucket interval (%s)", opts.Interval)
+	}
+
+	loc := opts.Location
+	if loc == nil {
+		loc = time.UTC
+	}
+	if loc == time.Local {
+		return nil, errors.New("bucket location must be loaded by time.LoadLocation, got Local")
+	}
+	zone := loc.String()
+
+	if opts.Fill && (opts.Start.IsZero() || opts.End.IsZero()) {
+		return nil, errors.New("start and end are required to fill the time series")
+	}
+
+	if len(aggregates) == 0 {
+		aggregates = []contract.Aggregate{contract.Count()}
+	}
+
+	s, err := Schema[T](db)
+	if err != nil {
+		return nil, err
+	}
+
+	exprs, err := aggregateExprs(s, aggregates)
+	if err != nil {
+		return nil, err
+	}
+
+	col := clause.Column{Table: clause.CurrentTable, Name: field.ColumnName}
+	sqls := []string{"date_trunc(?, ?::timestamptz, ?) AS bucket"}
+	vars := []any{string(opts.Interval), col, zone}
+	for i, expr := range exprs {
+		sqls = append(sqls, fmt.Sprintf("? AS v%d", i))
+		vars = append(vars, expr)
+	}
+
+	var model T
+	data, err := WhereQueryMap(WithContext(ctx, db).Model(&model), query)
+	if err != nil {
+		return nil, err
+	}
+	if !opts.Start.IsZero() {
+		data = data.Where(clause.Expr{SQL: "? >= ?", Vars: []any{col, opts.Start}})
+	}
+	if !opts.End.IsZero() {
+		data = data.Where(clause.Expr{SQL: "? < ?", Vars: []any{col, opts.End}})
+	}
+	// The bucket is referred by its position, an entity may have a column named bucket.
+	bucket := clause.Column{Name: "1", Raw: true}
+	data = data.
+		Clauses(clause.Select{Expression: clause.Expr{SQL: strings.Join(sqls, ", "), Vars: vars}}).
+		Clauses(clause.GroupBy{Columns: []clause.Column{bucket}})
+
+	var rows *sql.Rows
+	if opts.Fill {
+		columns := []string{`"series"."bucket"`}
+		for i := range exprs {
+			columns = append(columns, fmt.Sprintf(`"data"."v%d"`, i))
+		}
+		// The series is generated in wall time, so the buckets follow the daylight saving time of the location.
+		rows, err = WithContext(ctx, db).Raw(
+			fmt.Sprintf(`SELECT %s FROM (
+	SELECT generate_series(
+		date_trunc(?, ?::timestamptz AT TIME ZONE ?),
+		?::timestamptz AT TIME ZONE ? - interval '1 microsecond',
+		?::interval
+	) AT TIME ZONE ? AS bucket
+) AS series LEFT JOIN (?) AS data ON "data"."bucket" = "series"."bucket"
+ORDER BY "series"."bucket"`, strings.Join(columns, ", ")),
+			string(opts.Interval), opts.Start, zone,
+			opts.End, zone,
+			"1 "+string(opts.Interval),
+			zone,
+			data,
+		).Rows()
+	} else {
+		rows, err = data.Clauses(clause.OrderBy{Columns: []clause.OrderByColumn{{Column: bucket}}}).Rows()
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var results []contract.Bucket
+	for rows.Next() {
+		var start time.Time
+		values := make([]sql.NullFloat64, len(exprs))
+
+		dest := []any{&start}
+		for i := range values {
+			dest = append(dest, &values[i])
+		}
+		if err := rows.Scan(dest...); err != nil {
+			return nil, err
+		}
+
+		result := contract.Bucket{Start: start.In(loc), Values: make([]float64, len(values))}
+		for i, value := range values {
+			result.Values[i] = value.Float64
+		}
+		results = append(results, result)
+	}
+
+	return results, rows.Err()
+}
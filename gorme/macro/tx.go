@@ -0,0 +1,30 @@
+package macro
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+)
+
+type txKey struct{}
+
+// ContextWithTx returns a copy of ctx carrying the connection of the transaction tx.
+func ContextWithTx(ctx context.Context, tx *gorm.DB) context.Context {
+	return context.WithValue(ctx, txKey{}, tx.Statement.ConnPool)
+}
+
+// WithContext is like db.WithContext but runs the statement in the transaction
+// carried by ctx, if any. The configuration of db such as preloads is kept.
+func WithContext(ctx context.Context, db *gorm.DB) *gorm.DB {
+	tx := db.WithContext(ctx)
+	if pool, ok := ctx.Value(txKey{}).(gorm.ConnPool); ok {
+		tx.Statement.ConnPool = pool
+	}
+	return tx
+}
+
+// inTx reports whether ctx carries a transaction.
+func inTx(ctx context.Context) bool {
+	_, ok := ctx.Value(txKey{}).(gorm.ConnPool)
+	return ok
+}
@@ -0,0 +1,75 @@
+package macro
+
+import (
+	"context"
+	"reflect"
+	"time"
+
+	"github.com/raaaaaaaay86/go-persistence-extension/contract"
+	"github.com/raaaaaaaay86/go-persistence-extension/gorme/util"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// WindowCondition matches the time field of entity within the window at now.
+// The time field is the non-zero time.Time field of entity, e.g. mark.TargetTime.
+func WindowCondition(entity any, window contract.Window, now time.Time) (clause.Expression, error) {
+	field, err := util.ParseTargetField(entity, reflect.TypeOf(time.Time{}))
+	if err != nil {
+		return nil, err
+	}
+
+	start, end, err := window.Range(now)
+	if err != nil {
+		return nil, err
+	}
+
+	col := clause.Column{Table: clause.CurrentTable, Name: field.ColumnName}
+	return clause.Expr{SQL: "? >= ? AND ? < ?", Vars: []any{col, start, col, end}}, nil
+}
+
+func WindowFind[T any](
+	ctx context.Context,
+	db *gorm.DB,
+	entity contract.Selector,
+	window contract.Window,
+	now time.Time,
+	limit int,
+	sorts ...contract.Sort,
+) ([]*T, error) {
+	var results []*T
+
+	expr, err := WindowCondition(entity, window, now)
+	if err != nil {
+		return results, err
+	}
+
+	db, err = Order[T](WithContext(ctx, db).Where(expr), sorts)
+	if err != nil {
+		return results, err
+	}
+
+	if err := db.Limit(limit).Find(&results).Error; err != nil {
+		return results, err
+	}
+
+	return results, nil
+}
+
+func WindowPFind[T any](
+	ctx context.Context,
+	db *gorm.DB,
+	entity contract.Selector,
+	window contract.Window,
+	now time.Time,
+	page int,
+	pageSize int,
+	sorts ...contract.Sort,
+) (*contract.Pagination[T], error) {
+	expr, err := WindowCondition(entity, window, now)
+	if err != nil {
+		return nil, err
+	}
+
+	return Paginate[T](ctx, db, Where(expr), page, pageSize, sorts)
+}
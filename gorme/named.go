@@ -0,0 +1,281 @@
+package gorme
+
+import (
+	"bufio"
+	"context"
+	"database/sql"
+	"fmt"
+	"io/fs"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/raaaaaaaay86/go-persistence-extension/gorme/macro"
+	"github.com/raaaaaaaay86/go-persistence-extension/gorme/util"
+	"gorm.io/gorm"
+)
+
+// QueryKind is the result kind of a named query.
+type QueryKind string
+
+const (
+	// QueryKindMany returns every row, executed by QueryMany.
+	QueryKindMany QueryKind = "many"
+	// QueryKindOne returns the first row, executed by QueryOne.
+	QueryKindOne QueryKind = "one"
+	// QueryKindExec returns the affected rows, executed by NamedQueryRepository.Exec.
+	QueryKindExec QueryKind = "exec"
+)
+
+// NamedQuery is a query annotated in a .sql file.
+type NamedQuery struct {
+	Name string
+	Kind QueryKind
+	SQL  string
+	File string
+}
+
+var namedQueryAnnotation = regexp.MustCompile(`^--\s*name:\s*(\w+)\s+:(\w+)\s*$`)
+
+// NamedQueryRepository executes the named queries loaded from .sql files.
+// Every query starts with an annotation line giving its name and kind, and
+// ends at the next annotation or at the end of the file.
+//
+//	-- name: UsersByMinAge :many
+//	SELECT * FROM users WHERE age >= @min_age ORDER BY id;
+//
+//	-- name: UserByUsername :one
+//	SELECT * FROM users WHERE username = @username;
+//
+//	-- name: DeleteUsersByAge :exec
+//	DELETE FROM users WHERE age = @age;
+//
+// The @name placeholders are bound from the params of the execution, which is
+// a struct, a map[string]any or nil. A struct field is bound by both its name
+// and its column name, e.g. MinAge binds @MinAge and @min_age. A placeholder must
+// be followed by a space, a comma, a parenthesis or a line break, so write
+// CAST(@age AS int) instead of @age::int.
+type NamedQueryRepository struct {
+	db      *gorm.DB
+	queries map[string]NamedQuery
+}
+
+// NewNamedQueryRepository loads the named queries of every .sql file in fsys,
+// which is usually an embed.FS. Query names must be unique across the files.
+//
+//	//go:embed queries/*.sql
+//	var queries embed.FS
+//
+//	repo, err := NewNamedQueryRepository(db, queries)
+func NewNamedQueryRepository(db *gorm.DB, fsys fs.FS) (*NamedQueryRepository, error) {
+	queries := make(map[string]NamedQuery)
+
+	err := fs.WalkDir(fsys, ".", func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() || !strings.HasSuffix(path, ".sql") {
+			return nil
+		}
+
+		content, err := fs.ReadFile(fsys, path)
+		if err != nil {
+			return err
+		}
+
+		parsed, err := ParseNamedQueries(path, string(content))
+		if err != nil {
+			return err
+		}
+
+		for _, query := range parsed {
+			if previous, ok := queries[query.Name]; ok {
+				return fmt.Errorf("duplicated query name (%s) in %s and %s", query.Name, previous.File, query.File)
+			}
+			queries[query.Name] = query
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &NamedQueryRepository{db, queries}, nil
+}
+
+// MustNewNamedQueryRepository is like NewNamedQueryRepository but panics if the
+// queries can not be loaded.
+func MustNewNamedQueryRepository(db *gorm.DB, fsys fs.FS) *NamedQueryRepository {
+	repo, err := NewNamedQueryRepository(db, fsys)
+	if err != nil {
+		panic(err)
+	}
+	return repo
+}
+
+// ParseNamedQueries parses the named queries of a .sql file. Blank lines and
+// comments before the first annotation are ignored.
+func ParseNamedQueries(file string, content string) ([]NamedQuery, error) {
+	var queries []NamedQuery
+	var current *NamedQuery
+	var body strings.Builder
+
+	flush := func() error {
+		if current == nil {
+			return nil
+		}
+		current.SQL = strings.TrimRight(strings.TrimSpace(body.String()), ";")
+		if current.SQL == "" {
+			return fmt.Errorf("%s: query (%s) has no sql", file, current.Name)
+		}
+		queries = append(queries, *current)
+		body.Reset()
+		return nil
+	}
+
+	scanner := bufio.NewScanner(strings.NewReader(content))
+	for line := 1; scanner.Scan(); line++ {
+		text := scanner.Text()
+		trimmed := strings.TrimSpace(text)
+
+		if match := namedQueryAnnotation.FindStringSubmatch(trimmed); match != nil {
+			if err := flush(); err != nil {
+				return nil, err
+			}
+			kind := QueryKind(match[2])
+			switch kind {
+			case QueryKindMany, QueryKindOne, QueryKindExec:
+			default:
+				return nil, fmt.Errorf("%s:%d: unknown query kind (%s)", file, line, kind)
+			}
+			current = &NamedQuery{Name: match[1], Kind: kind, File: file}
+			continue
+		}
+
+		if current == nil {
+			if trimmed != "" && !strings.HasPrefix(trimmed, "--") {
+				return nil, fmt.Errorf("%s:%d: sql without name annotation", file, line)
+			}
+			continue
+		}
+
+		body.WriteString(text)
+		body.WriteByte('\n')
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	if err := flush(); err != nil {
+		return nil, err
+	}
+	return queries, nil
+}
+
+// DB implements Connector.
+func (r *NamedQueryRepository) DB() *gorm.DB {
+	return r.db
+}
+
+// Names returns the sorted names of the loaded queries.
+func (r *NamedQueryRepository) Names() []string {
+	names := make([]string, 0, len(r.queries))
+	for name := range r.queries {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Exec executes the :exec query and returns the affected rows.
+func (r *NamedQueryRepository) Exec(ctx context.Context, name string, params any) (int64, error) {
+	query, err := r.query(name, QueryKindExec)
+	if err != nil {
+		return 0, err
+	}
+
+	tx := macro.WithContext(ctx, r.db).Exec(query.SQL, namedArgs(params)...)
+	if tx.Error != nil {
+		return 0, tx.Error
+	}
+	return tx.RowsAffected, nil
+}
+
+// QueryMany executes the :many query and scans the rows into T.
+//
+//	users, err := QueryMany[entity.User](ctx, repo, "UsersByMinAge", struct{ MinAge int }{18})
+func QueryMany[T any](ctx context.Context, repo *NamedQueryRepository, name string, params any) ([]*T, error) {
+	var results []*T
+
+	query, err := repo.query(name, QueryKindMany)
+	if err != nil {
+		return results, err
+	}
+
+	if err := macro.WithContext(ctx, repo.db).Raw(query.SQL, namedArgs(params)...).Scan(&results).Error; err != nil {
+		return results, err
+	}
+
+	return results, nil
+}
+
+// QueryOne executes the :one query and scans the first row into T. It returns
+// gorm.ErrRecordNotFound if there is no row.
+func QueryOne[T any](ctx context.Context, repo *NamedQueryRepository, name string, params any) (*T, error) {
+	var result T
+
+	query, err := repo.query(name, QueryKindOne)
+	if err != nil {
+		return nil, err
+	}
+
+	tx := macro.WithContext(ctx, repo.db).Raw(query.SQL, namedArgs(params)...).Scan(&result)
+	if tx.Error != nil {
+		return nil, tx.Error
+	}
+	if tx.RowsAffected == 0 {
+		return nil, gorm.ErrRecordNotFound
+	}
+
+	return &result, nil
+}
+
+func (r *NamedQueryRepository) query(name string, kind QueryKind) (NamedQuery, error) {
+	query, ok := r.queries[name]
+	if !ok {
+		return NamedQuery{}, fmt.Errorf("unknown query (%s)", name)
+	}
+	if query.Kind != kind {
+		return NamedQuery{}, fmt.Errorf("query (%s) is :%s, not :%s", name, query.Kind, kind)
+	}
+	return query, nil
+}
+
+// namedArgs converts params into the named arguments of gorm.
+func namedArgs(params any) []any {
+	if params == nil {
+		return nil
+	}
+
+	switch p := params.(type) {
+	case map[string]any:
+		return []any{p}
+	case sql.NamedArg:
+		return []any{p}
+	}
+
+	v := reflect.Indirect(reflect.ValueOf(params))
+	if v.Kind() != reflect.Struct {
+		return []any{params}
+	}
+
+	named := make(map[string]any)
+	fields, values := util.FlattenStruct(v)
+	for i, field := range fields {
+		value := values[i].Interface()
+		named[field.Name] = value
+		named[util.ColumnName(field)] = value
+	}
+	return []any{named}
+}
@@ -0,0 +1,116 @@
+package gorme_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/raaaaaaaay86/go-persistence-extension/gorme"
+	"github.com/raaaaaaaay86/go-persistence-extension/gorme/repository"
+	"github.com/raaaaaaaay86/go-persistence-extension/gorme/util"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/gorm"
+)
+
+type NamedQueryOperationTestSuite struct {
+	suite.Suite
+	UserRepository *repository.UserRepository
+}
+
+func (s *NamedQueryOperationTestSuite) SetupTest() {
+	if err := s.Setup(); err != nil {
+		s.T().Fatalf("failed to setup NamedQueryOperationTestSuite: %s", err.Error())
+	}
+}
+
+func (s *NamedQueryOperationTestSuite) Setup() error {
+	db, err := util.CreateGormPostgreSqlConnection(&gorm.Config{TranslateError: true})
+	if err != nil {
+		return err
+	}
+
+	s.UserRepository, err = repository.NewUserRepositoryWith(db.Debug(), repository.WithNamedQueries())
+
+	return err
+}
+
+func (s *NamedQueryOperationTestSuite) Test_QueryMany() {
+	ctx := context.Background()
+
+	s.T().Log("Test_QueryMany: Find users with age at least 23")
+	users, err := s.UserRepository.UsersByMinAge(ctx, 23)
+	assert.NoError(s.T(), err)
+	assert.NotEmpty(s.T(), users)
+	for _, user := range users {
+		assert.GreaterOrEqual(s.T(), user.Age, 23)
+	}
+
+	s.T().Log("Test_QueryMany: Count users by age into DTO")
+	groups, err := gorme.QueryMany[AgeGroup](ctx, s.UserRepository.Queries, "UserCountByAge", nil)
+	assert.NoError(s.T(), err)
+	assert.NotEmpty(s.T(), groups)
+
+	s.T().Log("Test_QueryMany: Execute unknown query")
+	_, err = gorme.QueryMany[AgeGroup](ctx, s.UserRepository.Queries, "Unknown", nil)
+	assert.Error(s.T(), err)
+}
+
+func (s *NamedQueryOperationTestSuite) Test_QueryOne() {
+	ctx := context.Background()
+
+	s.T().Log("Test_QueryOne: Find user by username")
+	user, err := s.UserRepository.UserByUsername(ctx, "user1")
+	assert.NoError(s.T(), err)
+	assert.Equal(s.T(), "user1", user.Username)
+
+	s.T().Log("Test_QueryOne: Find non-exist user")
+	_, err = s.UserRepository.UserByUsername(ctx, "non-exist")
+	assert.True(s.T(), errors.Is(err, gorm.ErrRecordNotFound))
+}
+
+func (s *NamedQueryOperationTestSuite) Test_Exec() {
+	ctx := context.Background()
+
+	s.T().Log("Test_Exec: Touch users with age 20")
+	affected, err := s.UserRepository.Queries.Exec(ctx, "TouchUsersByAge", map[string]any{"age": 20})
+	assert.NoError(s.T(), err)
+	assert.Equal(s.T(), int64(5), affected)
+
+	s.T().Log("Test_Exec: Execute a :many query")
+	_, err = s.UserRepository.Queries.Exec(ctx, "UsersByMinAge", nil)
+	assert.Error(s.T(), err)
+}
+
+func TestNamedQueryOperationTestSuite(t *testing.T) {
+	suite.Run(t, new(NamedQueryOperationTestSuite))
+}
+
+func TestParseNamedQueries(t *testing.T) {
+	queries, err := gorme.ParseNamedQueries("user.sql", `
+-- users
+
+-- name: ActiveUsersByAge :many
+SELECT * FROM users
+WHERE age = @age;
+
+-- name: DeleteUser :exec
+DELETE FROM users WHERE id = @id;
+`)
+	assert.NoError(t, err)
+	assert.Len(t, queries, 2)
+	assert.Equal(t, "ActiveUsersByAge", queries[0].Name)
+	assert.Equal(t, gorme.QueryKindMany, queries[0].Kind)
+	assert.Equal(t, "SELECT * FROM users\nWHERE age = @age", queries[0].SQL)
+	assert.Equal(t, gorme.QueryKindExec, queries[1].Kind)
+	assert.Equal(t, "DELETE FROM users WHERE id = @id", queries[1].SQL)
+
+	_, err = gorme.ParseNamedQueries("user.sql", "-- name: Unknown :rows\nSELECT 1;")
+	assert.Error(t, err)
+
+	_, err = gorme.ParseNamedQueries("user.sql", "SELECT 1;\n-- name: One :one\nSELECT 1;")
+	assert.Error(t, err)
+
+	_, err = gorme.ParseNamedQueries("user.sql", "-- name: Empty :one\n")
+	assert.Error(t, err)
+}
@@ -0,0 +1,31 @@
+package gorme
+
+import (
+	"github.com/raaaaaaaay86/go-persistence-extension/contract"
+)
+
+// Option configures a repository.
+type Option func(*options)
+
+type options struct {
+	clock contract.Clock
+}
+
+// WithClock makes the repository read the current time from clock instead of
+// the system clock.
+//
+//	clock := contract.FixedClock{Time: time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)}
+//	repo := NewUltimateRepository[User, uint](db, WithClock(clock))
+func WithClock(clock contract.Clock) Option {
+	return func(o *options) {
+		o.clock = clock
+	}
+}
+
+func newOptions(opts []Option) options {
+	o := options{clock: contract.SystemClock{}}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
@@ -17,15 +17,21 @@ import (
 var _ = contract.Paginated[any, uint](&PaginationRepository[any, uint]{})
 
 type PaginationRepository[T any, Q contract.Identifier] struct {
-	db *gorm.DB
+	db    *gorm.DB
+	clock contract.Clock
 }
 
-func NewPaginationRepository[T any, Q contract.Identifier](db *gorm.DB) *PaginationRepository[T, Q] {
-	return &PaginationRepository[T, Q]{db}
+func NewPaginationRepository[T any, Q contract.Identifier](db *gorm.DB, opts ...Option) *PaginationRepository[T, Q] {
+	return &PaginationRepository[T, Q]{db, newOptions(opts).clock}
 }
 
-func NewEagerPaginationRepository[T any, Q contract.Identifier](db *gorm.DB) *PaginationRepository[T, Q] {
-	return &PaginationRepository[T, Q]{db.Preload(clause.Associations)}
+func NewEagerPaginationRepository[T any, Q contract.Identifier](db *gorm.DB, opts ...Option) *PaginationRepository[T, Q] {
+	return NewPaginationRepository[T, Q](db.Preload(clause.Associations), opts...)
+}
+
+// DB implements Connector.
+func (p *PaginationRepository[T, Q]) DB() *gorm.DB {
+	return p.db
 }
 
 // FindAll implements contract.Pagination.
@@ -35,20 +41,9 @@ func (p *PaginationRepository[T, Q]) PFindAll(
 	ctx context.Context,
 	page int,
 	pageSize int,
+	sorts ...contract.Sort,
 ) (*contract.Pagination[T], error) {
-	var results []T
-	offset := macro.Offset(page, pageSize)
-	if err := p.db.Offset(offset).Limit(pageSize).Find(&results).Error; err != nil {
-		return nil, err
-	}
-
-	var entity T
-	var total int64
-	if err := p.db.Model(entity).Count(&total).Error; err != nil {
-		return nil, err
-	}
-
-	return contract.NewPagination(results, page, pageSize, total), nil
+	return macro.Paginate[T](ctx, p.db, macro.Where(nil), page, pageSize, sorts)
 }
 
 // FindBy implements contract.Pagination.
@@ -59,236 +54,342 @@ func (p *PaginationRepository[T, Q]) PFindBy(
 	query contract.QueryMap,
 	page int,
 	pageSize int,
+	sorts ...contract.Sort,
 ) (*contract.Pagination[T], error) {
-	var results []T
-	if err := p.db.
-		Offset(macro.Offset(page, pageSize)).
-		Limit(pageSize).
-		Where(map[string]interface{}(query)).
-		Find(&results).Error; err != nil {
-		return nil, err
+	where := func(db *gorm.DB) (*gorm.DB, error) {
+		return macro.WhereQueryMap(db, query)
 	}
+	return macro.Paginate[T](ctx, p.db, where, page, pageSize, sorts)
+}
+
+// PFind implements contract.Paginated.
+//
+// PFind() will return records matched by the spec with pagination.
+func (p *PaginationRepository[T, Q]) PFind(
+	ctx context.Context,
+	spec contract.Spec,
+	page int,
+	pageSize int,
+	sorts ...contract.Sort,
+) (*contract.Pagination[T], error) {
+	return macro.PFindBySpec[T](ctx, p.db, spec, page, pageSize, sorts...)
+}
+
+// PFindByExample implements contract.Paginated.
+//
+// PFindByExample() is the paginated version of FindByExample().
+func (p *PaginationRepository[T, Q]) PFindByExample(
+	ctx context.Context,
+	example contract.Example[T],
+	page int,
+	pageSize int,
+	sorts ...contract.Sort,
+) (*contract.Pagination[T], error) {
+	return macro.PFindBySpec[T](ctx, p.db, example.Spec(), page, pageSize, sorts...)
+}
+
+// PLike implements contract.Paginated.
+//
+// PLike() is the paginated version of Like().
+func (p *PaginationRepository[T, Q]) PLike(
+	ctx context.Context,
+	entity T,
+	opts contract.LikeOptions,
+	page int,
+	pageSize int,
+	sorts ...contract.Sort,
+) (*contract.Pagination[T], error) {
+	return macro.LikePFind(ctx, p.db, entity, opts, page, pageSize, sorts...)
+}
 
-	var entity T
-	var total int64
-	if err := p.db.Model(entity).Count(&total).Error; err != nil {
+// PFindByOperator implements contract.Paginated.
+//
+// PFindByOperator() is the paginated version of FindByOperator().
+func (p *PaginationRepository[T, Q]) PFindByOperator(
+	ctx context.Context,
+	entity contract.Selector,
+	op contract.Operator,
+	value any,
+	page int,
+	pageSize int,
+	sorts ...contract.Sort,
+) (*contract.Pagination[T], error) {
+	enum, err := macro.OperatorOf(op)
+	if err != nil {
 		return nil, err
 	}
-
-	return contract.NewPagination(results, page, pageSize, total), nil
+	return macro.OperatorPFind[T](ctx, p.db, entity, enum, value, page, pageSize, sorts...)
 }
 
 func (p *PaginationRepository[T, Q]) PFindTimeBefore(
 	ctx context.Context,
-	entity T,
+	entity contract.Selector,
 	before time.Time,
 	page int,
 	pageSize int,
+	sorts ...contract.Sort,
 ) (*contract.Pagination[T], error) {
-	return macro.PFindByTime[T, Q](ctx, p.db, operator.LT, entity, before, page, pageSize)
+	return macro.PFindByTime[T, Q](ctx, p.db, operator.LT, entity, before, page, pageSize, sorts...)
 }
 
 func (p *PaginationRepository[T,
 	Q]) PFindTimeAfter(ctx context.Context,
-	entity T,
+	entity contract.Selector,
 	before time.Time,
 	page int,
 	pageSize int,
+	sorts ...contract.Sort,
 ) (*contract.Pagination[T], error) {
-	return macro.PFindByTime[T, Q](ctx, p.db, operator.GT, entity, before, page, pageSize)
+	return macro.PFindByTime[T, Q](ctx, p.db, operator.GT, entity, before, page, pageSize, sorts...)
 }
 
 func (p *PaginationRepository[T,
 	Q]) PFindTimeBetween(ctx context.Context,
-	entity T,
+	entity contract.Selector,
 	startAt time.Time,
 	endAt time.Time,
 	page int,
 	pageSize int,
+	sorts ...contract.Sort,
 ) (*contract.Pagination[T], error) {
-	f := fmt.Sprintf
-	var results []T
-
 	field, err := util.ParseTargetField(entity, reflect.TypeOf(time.Time{}))
 	if err != nil {
 		return nil, err
 	}
 
-	if err := p.db.
-		WithContext(ctx).
-		Offset(macro.Offset(page, pageSize)).
-		Limit(pageSize).
-		Where(f("%s > ? AND %s < ?", field.ColumnName, field.ColumnName), startAt, endAt).
-		Find(&results).Error; err != nil {
-		return nil, err
+	expr := clause.Expr{
+		SQL:  fmt.Sprintf("%s > ? AND %s < ?", field.ColumnName, field.ColumnName),
+		Vars: []any{startAt, endAt},
 	}
+	return macro.Paginate[T](ctx, p.db, macro.Where(expr), page, pageSize, sorts)
+}
 
-	total, err := macro.TotalCount[T](ctx, p.db, page, pageSize)
-	if err != nil {
-		return nil, err
-	}
+// PFindNull implements contract.Paginated.
+//
+// PFindNull() is the paginated version of FindNull().
+func (p *PaginationRepository[T, Q]) PFindNull(
+	ctx context.Context,
+	entity contract.Selector,
+	page int,
+	pageSize int,
+	sorts ...contract.Sort,
+) (*contract.Pagination[T], error) {
+	return macro.NullPFind[T](ctx, p.db, entity, true, page, pageSize, sorts...)
+}
 
-	return contract.NewPagination(results, page, pageSize, total), nil
+// PFindNotNull implements contract.Paginated.
+//
+// PFindNotNull() is the paginated version of FindNotNull().
+func (p *PaginationRepository[T, Q]) PFindNotNull(
+	ctx context.Context,
+	entity contract.Selector,
+	page int,
+	pageSize int,
+	sorts ...contract.Sort,
+) (*contract.Pagination[T], error) {
+	return macro.NullPFind[T](ctx, p.db, entity, false, page, pageSize, sorts...)
+}
+
+// PFindTimeWithin implements contract.Paginated.
+//
+// PFindTimeWithin() is the paginated version of FindTimeWithin().
+func (p *PaginationRepository[T, Q]) PFindTimeWithin(
+	ctx context.Context,
+	entity contract.Selector,
+	within time.Duration,
+	page int,
+	pageSize int,
+	sorts ...contract.Sort,
+) (*contract.Pagination[T], error) {
+	return macro.WindowPFind[T](ctx, p.db, entity, contract.Last(within), p.clock.Now(), page, pageSize, sorts...)
+}
+
+// PFindTimeInWindow implements contract.Paginated.
+//
+// PFindTimeInWindow() is the paginated version of FindTimeInWindow().
+func (p *PaginationRepository[T, Q]) PFindTimeInWindow(
+	ctx context.Context,
+	entity contract.Selector,
+	window contract.Window,
+	page int,
+	pageSize int,
+	sorts ...contract.Sort,
+) (*contract.Pagination[T], error) {
+	return macro.WindowPFind[T](ctx, p.db, entity, window, p.clock.Now(), page, pageSize, sorts...)
 }
 
 func (p *PaginationRepository[T, Q]) PFindIntGT(
 	ctx context.Context,
-	entity T,
+	entity contract.Selector,
 	value int,
 	page int,
 	pageSize int,
+	sorts ...contract.Sort,
 ) (*contract.Pagination[T], error) {
-	return macro.ComparePFind(ctx, p.db, entity, value, operator.GT, page, pageSize)
+	return macro.ComparePFind[T](ctx, p.db, entity, value, operator.GT, page, pageSize, sorts...)
 }
 
 func (p *PaginationRepository[T, Q]) PFindIntGTE(
 	ctx context.Context,
-	entity T,
+	entity contract.Selector,
 	value int,
 	page int,
 	pageSize int,
-) (*contract.Pagination[T],
-	error) {
-	return macro.ComparePFind(ctx, p.db, entity, value, operator.GTE, page, pageSize)
+	sorts ...contract.Sort,
+) (*contract.Pagination[T], error) {
+	return macro.ComparePFind[T](ctx, p.db, entity, value, operator.GTE, page, pageSize, sorts...)
 }
 
 func (p *PaginationRepository[T, Q]) PFindIntLT(
 	ctx context.Context,
-	entity T,
+	entity contract.Selector,
 	value int,
 	page int,
 	pageSize int,
+	sorts ...contract.Sort,
 ) (*contract.Pagination[T], error) {
-	return macro.ComparePFind(ctx, p.db, entity, value, operator.LT, page, pageSize)
+	return macro.ComparePFind[T](ctx, p.db, entity, value, operator.LT, page, pageSize, sorts...)
 }
 
 func (p *PaginationRepository[T, Q]) PFindIntLTE(
 	ctx context.Context,
-	entity T,
+	entity contract.Selector,
 	value int,
 	page int,
 	pageSize int,
-) (*contract.Pagination[T],
-	error) {
-	return macro.ComparePFind(ctx, p.db, entity, value, operator.LTE, page, pageSize)
+	sorts ...contract.Sort,
+) (*contract.Pagination[T], error) {
+	return macro.ComparePFind[T](ctx, p.db, entity, value, operator.LTE, page, pageSize, sorts...)
 }
 
 func (p *PaginationRepository[T, Q]) PFindUintGT(
 	ctx context.Context,
-	entity T,
+	entity contract.Selector,
 	value uint,
 	page int,
 	pageSize int,
+	sorts ...contract.Sort,
 ) (*contract.Pagination[T], error) {
-	return macro.ComparePFind(ctx, p.db, entity, value, operator.GT, page, pageSize)
+	return macro.ComparePFind[T](ctx, p.db, entity, value, operator.GT, page, pageSize, sorts...)
 }
 
 func (p *PaginationRepository[T, Q]) PFindUintGTE(
 	ctx context.Context,
-	entity T,
+	entity contract.Selector,
 	value uint,
 	page int,
 	pageSize int,
+	sorts ...contract.Sort,
 ) (*contract.Pagination[T], error) {
-	return macro.ComparePFind(ctx, p.db, entity, value, operator.GTE, page, pageSize)
+	return macro.ComparePFind[T](ctx, p.db, entity, value, operator.GTE, page, pageSize, sorts...)
 }
 
 func (p *PaginationRepository[T, Q]) PFindUintLT(
 	ctx context.Context,
-	entity T,
+	entity contract.Selector,
 	value uint,
 	page int,
 	pageSize int,
+	sorts ...contract.Sort,
 ) (*contract.Pagination[T], error) {
-	return macro.ComparePFind(ctx, p.db, entity, value, operator.LT, page, pageSize)
+	return macro.ComparePFind[T](ctx, p.db, entity, value, operator.LT, page, pageSize, sorts...)
 }
 
 func (p *PaginationRepository[T, Q]) PFindUintLTE(
 	ctx context.Context,
-	entity T,
+	entity contract.Selector,
 	value uint,
 	page int,
 	pageSize int,
+	sorts ...contract.Sort,
 ) (*contract.Pagination[T], error) {
-	return macro.ComparePFind(ctx, p.db, entity, value, operator.LTE, page, pageSize)
+	return macro.ComparePFind[T](ctx, p.db, entity, value, operator.LTE, page, pageSize, sorts...)
 }
 
 func (p *PaginationRepository[T, Q]) PFindFloat32GT(
 	ctx context.Context,
-	entity T,
+	entity contract.Selector,
 	value float32,
 	page int,
 	pageSize int,
+	sorts ...contract.Sort,
 ) (*contract.Pagination[T], error) {
-	return macro.ComparePFind(ctx, p.db, entity, value, operator.GT, page, pageSize)
+	return macro.ComparePFind[T](ctx, p.db, entity, value, operator.GT, page, pageSize, sorts...)
 }
 
 func (p *PaginationRepository[T, Q]) PFindFloat32GTE(
 	ctx context.Context,
-	entity T,
+	entity contract.Selector,
 	value float32,
 	page int,
 	pageSize int,
+	sorts ...contract.Sort,
 ) (*contract.Pagination[T], error) {
-	return macro.ComparePFind(ctx, p.db, entity, value, operator.GTE, page, pageSize)
+	return macro.ComparePFind[T](ctx, p.db, entity, value, operator.GTE, page, pageSize, sorts...)
 }
 
 func (p *PaginationRepository[T, Q]) PFindFloat32LT(
 	ctx context.Context,
-	entity T,
+	entity contract.Selector,
 	value float32,
 	page int,
 	pageSize int,
+	sorts ...contract.Sort,
 ) (*contract.Pagination[T], error) {
-	return macro.ComparePFind(ctx, p.db, entity, value, operator.LT, page, pageSize)
+	return macro.ComparePFind[T](ctx, p.db, entity, value, operator.LT, page, pageSize, sorts...)
 }
 
 func (p *PaginationRepository[T, Q]) PFindFloat32LTE(
 	ctx context.Context,
-	entity T,
+	entity contract.Selector,
 	value float32,
 	page int,
 	pageSize int,
+	sorts ...contract.Sort,
 ) (*contract.Pagination[T], error) {
-	return macro.ComparePFind(ctx, p.db, entity, value, operator.LTE, page, pageSize)
+	return macro.ComparePFind[T](ctx, p.db, entity, value, operator.LTE, page, pageSize, sorts...)
 }
 
 func (p *PaginationRepository[T, Q]) PFindFloat64GT(
 	ctx context.Context,
-	entity T,
+	entity contract.Selector,
 	value float64,
 	page int,
 	pageSize int,
+	sorts ...contract.Sort,
 ) (*contract.Pagination[T], error) {
-	return macro.ComparePFind(ctx, p.db, entity, value, operator.GT, page, pageSize)
+	return macro.ComparePFind[T](ctx, p.db, entity, value, operator.GT, page, pageSize, sorts...)
 }
 
 func (p *PaginationRepository[T, Q]) PFindFloat64GTE(
 	ctx context.Context,
-	entity T,
+	entity contract.Selector,
 	value float64,
 	page int,
 	pageSize int,
+	sorts ...contract.Sort,
 ) (*contract.Pagination[T], error) {
-	return macro.ComparePFind(ctx, p.db, entity, value, operator.GTE, page, pageSize)
+	return macro.ComparePFind[T](ctx, p.db, entity, value, operator.GTE, page, pageSize, sorts...)
 }
 
 func (p *PaginationRepository[T, Q]) PFindFloat64LT(
 	ctx context.Context,
-	entity T,
+	entity contract.Selector,
 	value float64,
 	page int,
 	pageSize int,
+	sorts ...contract.Sort,
 ) (*contract.Pagination[T], error) {
-	return macro.ComparePFind(ctx, p.db, entity, value, operator.LT, page, pageSize)
+	return macro.ComparePFind[T](ctx, p.db, entity, value, operator.LT, page, pageSize, sorts...)
 }
 
 func (p *PaginationRepository[T, Q]) PFindFloat64LTE(
 	ctx context.Context,
-	entity T,
+	entity contract.Selector,
 	value float64,
 	page int,
 	pageSize int,
+	sorts ...contract.Sort,
 ) (*contract.Pagination[T], error) {
-	return macro.ComparePFind(ctx, p.db, entity, value, operator.LTE, page, pageSize)
+	return macro.ComparePFind[T](ctx, p.db, entity, value, operator.LTE, page, pageSize, sorts...)
 }
@@ -2,10 +2,16 @@ package gorme_test
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"net/url"
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/raaaaaaaay86/go-persistence-extension/contract"
+	"github.com/raaaaaaaay86/go-persistence-extension/gorme"
 	"github.com/raaaaaaaay86/go-persistence-extension/gorme/entity"
 	"github.com/raaaaaaaay86/go-persistence-extension/gorme/repository"
 	"github.com/raaaaaaaay86/go-persistence-extension/gorme/util"
@@ -232,6 +238,435 @@ func (s *PaginationOperationTestSuite) TestPFindIntLTE() {
 	}
 }
 
+func (s *PaginationOperationTestSuite) TestPFind() {
+	s.T().Log("Test_TestPFind: start")
+
+	spec := contract.And(
+		contract.Between("age", 21, 23),
+		contract.Like("email", "%@mail.com"),
+	)
+
+	currentPage := 1
+	for {
+		pagination, err := s.UserRepository.PFind(context.Background(), spec, currentPage, 1)
+		if err != nil {
+			s.T().Fatalf("Test_TestPFind: failed (%s)", err.Error())
+		}
+
+		for _, user := range pagination.Results {
+			assert.GreaterOrEqual(s.T(), user.Age, 21)
+			assert.LessOrEqual(s.T(), user.Age, 23)
+		}
+
+		if !pagination.HasNext() {
+			break
+		}
+
+		currentPage++
+	}
+}
+
+func (s *PaginationOperationTestSuite) TestPFindByOperator() {
+	s.T().Log("Test_TestPFindByOperator: start")
+
+	currentPage := 1
+	for {
+		target := entity.User{
+			Age: mark.TargetInt,
+		}
+
+		pagination, err := s.UserRepository.PFindByOperator(context.Background(), target, contract.OpBetween, []int{21, 23}, currentPage, 1)
+		if err != nil {
+			s.T().Fatalf("Test_TestPFindByOperator: failed (%s)", err.Error())
+		}
+
+		for _, user := range pagination.Results {
+			assert.GreaterOrEqual(s.T(), user.Age, 21)
+			assert.LessOrEqual(s.T(), user.Age, 23)
+		}
+
+		if !pagination.HasNext() {
+			break
+		}
+
+		currentPage++
+	}
+}
+
+func (s *PaginationOperationTestSuite) TestPFindCompare() {
+	s.T().Log("Test_TestPFindCompare: start")
+
+	currentPage := 1
+	for {
+		target := entity.User{
+			Age: mark.TargetInt,
+		}
+
+		pagination, err := gorme.PFindCompare[entity.User](context.Background(), s.UserRepository, target, contract.OpLt, 21, currentPage, 1)
+		if err != nil {
+			s.T().Fatalf("Test_TestPFindCompare: failed (%s)", err.Error())
+		}
+
+		for _, user := range pagination.Results {
+			assert.Less(s.T(), user.Age, 21)
+		}
+
+		if !pagination.HasNext() {
+			break
+		}
+
+		currentPage++
+	}
+}
+
+func (s *PaginationOperationTestSuite) TestPFindByFilter() {
+	s.T().Log("Test_TestPFindByFilter: start")
+
+	filter := entity.UserFilter{
+		Emails: []string{"test@mail.com"},
+		MinAge: &[]int{23}[0],
+	}
+
+	query, err := filter.ToMap()
+	if err != nil {
+		s.T().Fatalf("Test_TestPFindByFilter: failed (%s)", err.Error())
+	}
+
+	currentPage := 1
+	for {
+		pagination, err := s.UserRepository.PFindBy(context.Background(), query, currentPage, 1)
+		if err != nil {
+			s.T().Fatalf("Test_TestPFindByFilter: failed (%s)", err.Error())
+		}
+
+		for _, user := range pagination.Results {
+			assert.GreaterOrEqual(s.T(), user.Age, 23)
+		}
+
+		if !pagination.HasNext() {
+			break
+		}
+
+		currentPage++
+	}
+}
+
+func (s *PaginationOperationTestSuite) TestPLike() {
+	s.T().Log("Test_TestPLike: start")
+
+	opts := contract.LikeOptions{Match: contract.MatchPrefix, Case: contract.CaseInsensitive}
+
+	count := 0
+	currentPage := 1
+	for {
+		pagination, err := s.UserRepository.PLike(context.Background(), entity.User{Username: "USER1"}, opts, currentPage, 1)
+		if err != nil {
+			s.T().Fatalf("Test_TestPLike: failed (%s)", err.Error())
+		}
+
+		for _, user := range pagination.Results {
+			assert.True(s.T(), strings.HasPrefix(user.Username, "user1"))
+			count++
+		}
+
+		if !pagination.HasNext() {
+			break
+		}
+
+		currentPage++
+	}
+	assert.Equal(s.T(), 2, count)
+}
+
+func (s *PaginationOperationTestSuite) TestPFindByExample() {
+	s.T().Log("Test_TestPFindByExample: start")
+
+	example := contract.Example[entity.User]{
+		Probe:   entity.User{Email: "@mail.com", Age: 20},
+		Matcher: contract.ExampleMatcher{Fields: map[string]contract.StringMatcher{"Email": {Match: contract.MatchSuffix}}},
+	}
+
+	count := 0
+	currentPage := 1
+	for {
+		pagination, err := s.UserRepository.PFindByExample(context.Background(), example, currentPage, 5)
+		if err != nil {
+			s.T().Fatalf("Test_TestPFindByExample: failed (%s)", err.Error())
+		}
+
+		for _, user := range pagination.Results {
+			assert.Equal(s.T(), 20, user.Age)
+			count++
+		}
+
+		if !pagination.HasNext() {
+			break
+		}
+
+		currentPage++
+	}
+	assert.Equal(s.T(), 5, count)
+}
+
+func (s *PaginationOperationTestSuite) TestPFindByAssociation() {
+	s.T().Log("Test_TestPFindByAssociation: start")
+
+	pagination, err := s.UserRepository.PFind(context.Background(), contract.Has("Orders", nil), 1, 10)
+	if err != nil {
+		s.T().Fatalf("Test_TestPFindByAssociation: failed (%s)", err.Error())
+	}
+
+	// user1 has 3 orders but is counted once.
+	assert.Equal(s.T(), int64(3), pagination.TotalCount)
+	assert.Len(s.T(), pagination.Results, 3)
+}
+
+func (s *PaginationOperationTestSuite) TestPFindAllSorted() {
+	s.T().Log("Test_TestPFindAllSorted: start")
+
+	var previous time.Time
+	currentPage := 1
+	for {
+		pagination, err := s.UserRepository.PFindAll(context.Background(), currentPage, 3, contract.Asc("birthday"))
+		if err != nil {
+			s.T().Fatalf("Test_TestPFindAllSorted: failed (%s)", err.Error())
+		}
+
+		for _, user := range pagination.Results {
+			assert.False(s.T(), user.Birthday.Before(previous))
+			previous = user.Birthday
+		}
+
+		if !pagination.HasNext() {
+			break
+		}
+
+		currentPage++
+	}
+}
+
+func (s *PaginationOperationTestSuite) TestPFindAs() {
+	s.T().Log("Test_TestPFindAs: start")
+
+	type UserSummary struct {
+		ID       uint
+		Username string
+	}
+
+	currentPage := 1
+	for {
+		pagination, err := gorme.PFindAs[entity.User, UserSummary](context.Background(), s.UserRepository, nil, currentPage, 4)
+		if err != nil {
+			s.T().Fatalf("Test_TestPFindAs: failed (%s)", err.Error())
+		}
+
+		for _, summary := range pagination.Results {
+			assert.NotEmpty(s.T(), summary.ID)
+			assert.NotEmpty(s.T(), summary.Username)
+		}
+
+		if !pagination.HasNext() {
+			break
+		}
+
+		currentPage++
+	}
+}
+
+func (s *PaginationOperationTestSuite) TestPFindByFilteredTotal() {
+	s.T().Log("Test_TestPFindByFilteredTotal: start")
+	ctx := context.Background()
+
+	count, err := s.UserRepository.CountBy(ctx, contract.QueryMap{"age": 20})
+	assert.NoError(s.T(), err)
+
+	pagination, err := s.UserRepository.PFindBy(ctx, contract.QueryMap{"age": 20}, 1, 2)
+	assert.NoError(s.T(), err)
+	assert.Equal(s.T(), count, pagination.TotalCount)
+	assert.Equal(s.T(), contract.TotalPage(count, 2), pagination.TotalPage)
+	assert.True(s.T(), pagination.HasNext())
+
+	beforeAt := time.Date(2000, 8, 1, 0, 0, 0, 0, time.UTC)
+	pagination, err = s.UserRepository.PFindTimeBefore(ctx, entity.User{Birthday: mark.TargetTime}, beforeAt, 1, 1)
+	assert.NoError(s.T(), err)
+	count, err = s.UserRepository.CountBy(ctx, contract.QueryMap{"birthday": contract.Lt("birthday", beforeAt)})
+	assert.NoError(s.T(), err)
+	assert.Equal(s.T(), count, pagination.TotalCount)
+
+	startAt := time.Date(2000, 5, 1, 0, 0, 0, 0, time.UTC)
+	pagination, err = s.UserRepository.PFindTimeBetween(ctx, entity.User{Birthday: mark.TargetTime}, startAt, beforeAt, 1, 1)
+	assert.NoError(s.T(), err)
+	count, err = s.UserRepository.CountBy(ctx, contract.QueryMap{"birthday": contract.And(
+		contract.Gt("birthday", startAt),
+		contract.Lt("birthday", beforeAt),
+	)})
+	assert.NoError(s.T(), err)
+	assert.Equal(s.T(), count, pagination.TotalCount)
+}
+
+func (s *PaginationOperationTestSuite) TestPFindCountMode() {
+	s.T().Log("Test_TestPFindCountMode: start")
+	ctx := context.Background()
+
+	exact, err := s.UserRepository.PFindAll(ctx, 1, 3)
+	assert.NoError(s.T(), err)
+	assert.Equal(s.T(), contract.CountExact, exact.Count)
+
+	s.T().Log("Test_TestPFindCountMode: walk through all pages without counting")
+	count := 0
+	currentPage := 1
+	for {
+		pagination, err := s.UserRepository.PFindAll(gorme.ContextWithCountMode(ctx, contract.CountNone), currentPage, 3)
+		if err != nil {
+			s.T().Fatalf("Test_TestPFindCountMode: failed (%s)", err.Error())
+		}
+		assert.Equal(s.T(), int64(-1), pagination.TotalCount)
+		assert.Equal(s.T(), -1, pagination.TotalPage)
+		count += len(pagination.Results)
+
+		if !pagination.HasNext() {
+			break
+		}
+		currentPage++
+	}
+	assert.Equal(s.T(), exact.TotalCount, int64(count))
+
+	s.T().Log("Test_TestPFindCountMode: estimate the total")
+	estimated, err := s.UserRepository.PFindAll(gorme.ContextWithCountMode(ctx, contract.CountEstimate), 1, 3)
+	assert.NoError(s.T(), err)
+	assert.Equal(s.T(), contract.CountEstimate, estimated.Count)
+	assert.Greater(s.T(), estimated.TotalCount, int64(3))
+	assert.Equal(s.T(), exact.Results, estimated.Results)
+
+	s.T().Log("Test_TestPFindCountMode: the last page is counted exactly")
+	last, err := s.UserRepository.PFindAll(gorme.ContextWithCountMode(ctx, contract.CountEstimate), exact.TotalPage, 3)
+	assert.NoError(s.T(), err)
+	assert.Equal(s.T(), exact.TotalCount, last.TotalCount)
+	assert.False(s.T(), last.HasNext())
+
+	s.T().Log("Test_TestPFindCountMode: unknown count mode")
+	_, err = s.UserRepository.PFindAll(gorme.ContextWithCountMode(ctx, "unknown"), 1, 3)
+	assert.Error(s.T(), err)
+}
+
+func (s *PaginationOperationTestSuite) TestPFindInTransaction() {
+	s.T().Log("Test_TestPFindInTransaction: start")
+	ctx := context.Background()
+	rollback := errors.New("rollback")
+
+	err := gorme.Transaction(ctx, s.UserRepository, func(ctx context.Context) error {
+		user := entity.User{Username: "pagination", Email: "pagination@mail.com", Age: 99}
+		if err := s.UserRepository.Create(ctx, &user); err != nil {
+			return err
+		}
+
+		// The page and its count see the uncommitted user of the transaction.
+		pagination, err := s.UserRepository.PFindBy(ctx, contract.QueryMap{"age": 99}, 1, 10)
+		assert.NoError(s.T(), err)
+		assert.Equal(s.T(), int64(1), pagination.TotalCount)
+		assert.Len(s.T(), pagination.Results, 1)
+		return rollback
+	})
+	assert.ErrorIs(s.T(), err, rollback)
+}
+
+func TestNewPagination(t *testing.T) {
+	t.Log("TestNewPagination: count the last partial page")
+	pagination := contract.NewPagination([]int{1, 2, 3, 4, 5}, 1, 5, 11)
+	assert.Equal(t, 3, pagination.TotalPage)
+	assert.True(t, pagination.HasNext())
+
+	pagination = contract.NewPagination([]int{11}, 3, 5, 11)
+	assert.Equal(t, 3, pagination.TotalPage)
+	assert.False(t, pagination.HasNext())
+
+	pagination = contract.NewPagination([]int{}, 1, 5, 0)
+	assert.Equal(t, 0, pagination.TotalPage)
+	assert.False(t, pagination.HasNext())
+
+	t.Log("TestNewPagination: unknown total")
+	pagination = contract.NewPagination([]int{1}, 1, 5, -1)
+	assert.Equal(t, -1, pagination.TotalPage)
+	assert.Equal(t, contract.CountNone, pagination.Count)
+}
+
+func TestPaginationMetadata(t *testing.T) {
+	pagination := contract.NewPagination([]int{6, 7, 8, 9, 10}, 2, 5, 11)
+
+	t.Log("TestPaginationMetadata: adjacent pages and item positions")
+	assert.True(t, pagination.HasPrev())
+	assert.Equal(t, 3, pagination.NextPage())
+	assert.Equal(t, 1, pagination.PrevPage())
+	assert.Equal(t, int64(6), pagination.FirstItem())
+	assert.Equal(t, int64(10), pagination.LastItem())
+
+	t.Log("TestPaginationMetadata: marshal with snake_case keys")
+	encoded, err := json.Marshal(pagination)
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{
+		"page": 2, "page_size": 5, "total_page": 3, "total_count": 11, "count": "exact",
+		"has_next": true, "has_prev": true, "next_page": 3, "prev_page": 1,
+		"first_item": 6, "last_item": 10, "results": [6, 7, 8, 9, 10]
+	}`, string(encoded))
+
+	var decoded contract.Pagination[int]
+	assert.NoError(t, json.Unmarshal(encoded, &decoded))
+	assert.Equal(t, *pagination, decoded)
+
+	t.Log("TestPaginationMetadata: marshal an uncounted first page")
+	encoded, err = json.Marshal(contract.NewPagination([]int(nil), 1, 5, -1))
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{
+		"page": 1, "page_size": 5, "total_page": null, "total_count": null, "count": "none",
+		"has_next": false, "has_prev": false, "next_page": null, "prev_page": null,
+		"first_item": 0, "last_item": 0, "results": []
+	}`, string(encoded))
+
+	t.Log("TestPaginationMetadata: link the pages keeping the other parameters")
+	base, _ := url.Parse("https://api.example.com/users?sort=age,desc&page=2&size=5")
+	links := strings.Split(pagination.LinkHeader(base, contract.PageRequestOptions{}), ", ")
+	assert.Equal(t, []string{
+		`<https://api.example.com/users?page=3&size=5&sort=age%2Cdesc>; rel="next"`,
+		`<https://api.example.com/users?page=1&size=5&sort=age%2Cdesc>; rel="prev"`,
+		`<https://api.example.com/users?page=1&size=5&sort=age%2Cdesc>; rel="first"`,
+		`<https://api.example.com/users?page=3&size=5&sort=age%2Cdesc>; rel="last"`,
+	}, links)
+}
+
+func TestParsePageRequest(t *testing.T) {
+	opts := contract.PageRequestOptions{MaxSize: 50, Sortable: []string{"age", "username"}}
+
+	t.Log("TestParsePageRequest: defaults")
+	req, err := contract.ParsePageRequest(url.Values{}, contract.PageRequestOptions{DefaultSorts: []contract.Sort{contract.Asc("id")}})
+	assert.NoError(t, err)
+	assert.Equal(t, contract.PageRequest{Page: 1, Size: 20, Sorts: []contract.Sort{contract.Asc("id")}}, req)
+
+	t.Log("TestParsePageRequest: page, size and sorts")
+	values, _ := url.ParseQuery("page=3&size=10&sort=age,desc&sort=username")
+	req, err = contract.ParsePageRequest(values, opts)
+	assert.NoError(t, err)
+	assert.Equal(t, contract.PageRequest{Page: 3, Size: 10, Sorts: []contract.Sort{contract.Desc("age"), contract.Asc("username")}}, req)
+	assert.Equal(t, 20, req.Offset())
+
+	t.Log("TestParsePageRequest: lower the size to the max size")
+	req, err = contract.ParsePageRequest(url.Values{"size": {"1000"}}, opts)
+	assert.NoError(t, err)
+	assert.Equal(t, 50, req.Size)
+
+	t.Log("TestParsePageRequest: custom parameters")
+	req, err = contract.ParsePageRequest(url.Values{"p": {"2"}, "per_page": {"5"}}, contract.PageRequestOptions{PageParam: "p", SizeParam: "per_page"})
+	assert.NoError(t, err)
+	assert.Equal(t, 2, req.Page)
+	assert.Equal(t, 5, req.Size)
+
+	t.Log("TestParsePageRequest: invalid requests")
+	for _, query := range []string{"page=0", "page=x", "size=-1", "sort=email", "sort=age,up", "sort=,desc"} {
+		values, _ := url.ParseQuery(query)
+		_, err := contract.ParsePageRequest(values, opts)
+		assert.Error(t, err, query)
+	}
+}
+
 func TestPaginationOperationTestSuite(t *testing.T) {
 	suite.Run(t, new(PaginationOperationTestSuite))
 }
@@ -0,0 +1,39 @@
+package gorme
+
+import (
+	"context"
+
+	"github.com/raaaaaaaay86/go-persistence-extension/contract"
+	"github.com/raaaaaaaay86/go-persistence-extension/gorme/macro"
+)
+
+// FindAs returns records of T matched by the spec as D. Only the columns of T
+// needed by the fields of D are selected. A field of D matches a column of T by
+// column name first, then by field name.
+//
+//	type UserSummary struct {
+//		ID       uint
+//		Username string
+//	}
+//	// SELECT "users"."id", "users"."username" FROM "users" WHERE "age" >= 18
+//	results, err := FindAs[entity.User, UserSummary](ctx, repo, contract.Gte("age", 18), contract.FindOptions{})
+func FindAs[T any, D any](
+	ctx context.Context,
+	repo Connector,
+	spec contract.Spec,
+	opts contract.FindOptions,
+) ([]*D, error) {
+	return macro.ProjectFind[T, D](ctx, repo.DB(), spec, opts)
+}
+
+// PFindAs is the paginated version of FindAs.
+func PFindAs[T any, D any](
+	ctx context.Context,
+	repo Connector,
+	spec contract.Spec,
+	page int,
+	pageSize int,
+	sorts ...contract.Sort,
+) (*contract.Pagination[D], error) {
+	return macro.ProjectPFind[T, D](ctx, repo.DB(), spec, page, pageSize, sorts...)
+}
@@ -0,0 +1,109 @@
+package gorme
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/raaaaaaaay86/go-persistence-extension/contract"
+	"github.com/raaaaaaaay86/go-persistence-extension/gorme/macro"
+	"gorm.io/gorm"
+)
+
+// Transaction runs fn in a transaction on the database of repo. The ctx passed
+// to fn carries the transaction, every repository method and Query called with
+// it runs in the transaction, even on another repository of the same database.
+// The transaction is committed if fn returns nil, otherwise it's rolled back.
+// A nested Transaction uses a savepoint.
+//
+//	err := Transaction(ctx, userRepository, func(ctx context.Context) error {
+//		if err := userRepository.Create(ctx, &user); err != nil {
+//			return err
+//		}
+//		return profileRepository.Create(ctx, &profile)
+//	})
+func Transaction(ctx context.Context, repo Connector, fn func(ctx context.Context) error) error {
+	return macro.WithContext(ctx, repo.DB()).Transaction(func(tx *gorm.DB) error {
+		return fn(macro.ContextWithTx(ctx, tx))
+	})
+}
+
+// ContextWithTx returns a copy of ctx carrying a transaction begun outside of
+// gorme, so the repositories called with it run in the transaction.
+func ContextWithTx(ctx context.Context, tx *gorm.DB) context.Context {
+	return macro.ContextWithTx(ctx, tx)
+}
+
+// ContextWithCountMode returns a copy of ctx making the paginated methods called
+// with it count their total by mode. Default is contract.CountExact.
+//
+//	// Skip the count of a feed, HasNext is still known
+//	ctx = gorme.ContextWithCountMode(ctx, contract.CountNone)
+//	page, err := userRepository.PFindAll(ctx, 3, 20)
+func ContextWithCountMode(ctx context.Context, mode contract.CountMode) context.Context {
+	return macro.ContextWithCountMode(ctx, mode)
+}
+
+// Query runs a raw SQL query on the database of repo and scans the rows into T,
+// which can be an entity or a DTO. Columns are matched with the fields of T the
+// same way as gorm. The query runs in the transaction carried by ctx, if any.
+//
+// args are bound to the ? placeholders, or to the @name placeholders when they are
+// sql.NamedArg, a map[string]any or a struct.
+//
+//	type AgeGroup struct {
+//		Age   int
+//		Total int64
+//	}
+//	results, err := Query[AgeGroup](ctx, repo,
+//		"SELECT age, count(*) AS total FROM users WHERE email = @email GROUP BY age ORDER BY age",
+//		sql.Named("email", "test@mail.com"),
+//	)
+func Query[T any](ctx context.Context, repo Connector, sql string, args ...any) ([]*T, error) {
+	var results []*T
+
+	if err := macro.WithContext(ctx, repo.DB()).Raw(sql, args...).Scan(&results).Error; err != nil {
+		return results, err
+	}
+
+	return results, nil
+}
+
+// QueryPage is the paginated version of Query. The page is selected by appending
+// LIMIT and OFFSET to sql, and the total is counted over sql as a subquery. So sql
+// must not end with LIMIT or OFFSET, and should have an ORDER BY for stable pages.
+func QueryPage[T any](
+	ctx context.Context,
+	repo Connector,
+	sql string,
+	page int,
+	pageSize int,
+	args ...any,
+) (*contract.Pagination[T], error) {
+	sql = strings.TrimRight(strings.TrimSpace(sql), ";")
+
+	find := func(ctx context.Context, offset int, limit int) ([]T, error) {
+		var results []T
+		paged := fmt.Sprintf("%s\nLIMIT %d OFFSET %d", sql, limit, offset)
+		if err := macro.WithContext(ctx, repo.DB()).Raw(paged, args...).Scan(&results).Error; err != nil {
+			return nil, err
+		}
+		return results, nil
+	}
+
+	count := func(ctx context.Context, mode contract.CountMode) (int64, error) {
+		db := macro.WithContext(ctx, repo.DB())
+		if mode == contract.CountEstimate {
+			return macro.EstimateRawCount(db, sql, args...)
+		}
+
+		var total int64
+		count := fmt.Sprintf("SELECT count(*) FROM (\n%s\n) AS gorme_query", sql)
+		if err := db.Raw(count, args...).Scan(&total).Error; err != nil {
+			return 0, err
+		}
+		return total, nil
+	}
+
+	return macro.PaginateFunc(ctx, repo.DB(), page, pageSize, find, count)
+}
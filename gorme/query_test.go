@@ -0,0 +1,153 @@
+package gorme_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/raaaaaaaay86/go-persistence-extension/gorme"
+	"github.com/raaaaaaaay86/go-persistence-extension/gorme/entity"
+	"github.com/raaaaaaaay86/go-persistence-extension/gorme/repository"
+	"github.com/raaaaaaaay86/go-persistence-extension/gorme/util"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/gorm"
+)
+
+type QueryOperationTestSuite struct {
+	suite.Suite
+	UserRepository *repository.UserRepository
+}
+
+func (s *QueryOperationTestSuite) SetupTest() {
+	if err := s.Setup(); err != nil {
+		s.T().Fatalf("failed to setup QueryOperationTestSuite: %s", err.Error())
+	}
+}
+
+func (s *QueryOperationTestSuite) Setup() error {
+	db, err := util.CreateGormPostgreSqlConnection(&gorm.Config{TranslateError: true})
+	if err != nil {
+		return err
+	}
+
+	s.UserRepository = repository.NewUserRepository(db.Debug())
+
+	return nil
+}
+
+type AgeGroup struct {
+	Age   int
+	Total int64
+}
+
+func (s *QueryOperationTestSuite) Test_Query() {
+	ctx := context.Background()
+
+	s.T().Log("Test_Query: Count users by age with named parameter")
+	groups, err := gorme.Query[AgeGroup](
+		ctx,
+		s.UserRepository,
+		"SELECT age, count(*) AS total FROM users WHERE email = @email AND deleted_at IS NULL GROUP BY age ORDER BY age",
+		sql.Named("email", "test@mail.com"),
+	)
+	assert.NoError(s.T(), err)
+	assert.NotEmpty(s.T(), groups)
+	for _, group := range groups {
+		if group.Age == 20 {
+			assert.Equal(s.T(), int64(5), group.Total)
+		}
+	}
+
+	s.T().Log("Test_Query: Find users into entity")
+	users, err := gorme.Query[entity.User](ctx, s.UserRepository, "SELECT * FROM users WHERE username = ?", "user1")
+	assert.NoError(s.T(), err)
+	assert.Len(s.T(), users, 1)
+	assert.Equal(s.T(), "user1", users[0].Username)
+}
+
+func (s *QueryOperationTestSuite) Test_QueryPage() {
+	ctx := context.Background()
+
+	s.T().Log("Test_QueryPage: Paginate users with age 20")
+	currentPage := 1
+	count := 0
+	for {
+		pagination, err := gorme.QueryPage[entity.User](
+			ctx,
+			s.UserRepository,
+			"SELECT * FROM users WHERE age = @age AND deleted_at IS NULL ORDER BY id;",
+			currentPage,
+			2,
+			map[string]any{"age": 20},
+		)
+		if err != nil {
+			s.T().Fatalf("Test_QueryPage: failed (%s)", err.Error())
+		}
+		assert.Equal(s.T(), int64(5), pagination.TotalCount)
+
+		for _, user := range pagination.Results {
+			assert.Equal(s.T(), 20, user.Age)
+			count++
+		}
+
+		if !pagination.HasNext() {
+			break
+		}
+
+		currentPage++
+	}
+	assert.NotZero(s.T(), count)
+}
+
+func (s *QueryOperationTestSuite) Test_Transaction() {
+	ctx := context.Background()
+	rollback := errors.New("rollback")
+
+	s.T().Log("Test_Transaction: Rollback a created user")
+	user := &entity.User{
+		Username: fmt.Sprintf("transaction_%d", time.Now().UnixNano()),
+		Email:    "test@mail.com",
+		Age:      30,
+		Birthday: time.Now(),
+	}
+	err := gorme.Transaction(ctx, s.UserRepository, func(ctx context.Context) error {
+		if err := s.UserRepository.Create(ctx, user); err != nil {
+			return err
+		}
+
+		users, err := gorme.Query[entity.User](ctx, s.UserRepository, "SELECT * FROM users WHERE id = ?", user.ID)
+		if err != nil {
+			return err
+		}
+		assert.Len(s.T(), users, 1)
+
+		return rollback
+	})
+	assert.ErrorIs(s.T(), err, rollback)
+
+	exists, err := s.UserRepository.ExistsById(ctx, user.ID)
+	assert.NoError(s.T(), err)
+	assert.False(s.T(), exists)
+
+	s.T().Log("Test_Transaction: Commit a created user")
+	user.ID = 0
+	err = gorme.Transaction(ctx, s.UserRepository, func(ctx context.Context) error {
+		return s.UserRepository.Create(ctx, user)
+	})
+	assert.NoError(s.T(), err)
+
+	exists, err = s.UserRepository.ExistsById(ctx, user.ID)
+	assert.NoError(s.T(), err)
+	assert.True(s.T(), exists)
+
+	_, err = s.UserRepository.DeleteById(ctx, user.ID)
+	assert.NoError(s.T(), err)
+}
+
+func TestQueryOperationTestSuite(t *testing.T) {
+	suite.Run(t, new(QueryOperationTestSuite))
+}
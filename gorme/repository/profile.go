@@ -0,0 +1,25 @@
+package repository
+
+import (
+	"github.com/raaaaaaaay86/go-persistence-extension/contract"
+	"github.com/raaaaaaaay86/go-persistence-extension/gorme"
+	"github.com/raaaaaaaay86/go-persistence-extension/gorme/entity"
+	"gorm.io/gorm"
+)
+
+type ProfileRepository struct {
+	db *gorm.DB
+	contract.Ultimate[entity.Profile, uint]
+}
+
+func NewProfileRepository(db *gorm.DB) *ProfileRepository {
+	return &ProfileRepository{
+		db:       db,
+		Ultimate: gorme.NewUltimateRepository[entity.Profile, uint](db),
+	}
+}
+
+// DB implements gorme.Connector.
+func (r *ProfileRepository) DB() *gorm.DB {
+	return r.db
+}
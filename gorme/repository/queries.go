@@ -0,0 +1,6 @@
+package repository
+
+import "embed"
+
+//go:embed queries/*.sql
+var queries embed.FS
@@ -0,0 +1,21 @@
+-- Queries of the users table.
+
+-- name: UsersByMinAge :many
+SELECT *
+FROM users
+WHERE age >= @min_age
+  AND deleted_at IS NULL
+ORDER BY id;
+
+-- name: UserByUsername :one
+SELECT * FROM users WHERE username = @username AND deleted_at IS NULL;
+
+-- name: UserCountByAge :many
+SELECT age, count(*) AS total
+FROM users
+WHERE deleted_at IS NULL
+GROUP BY age
+ORDER BY age;
+
+-- name: TouchUsersByAge :exec
+UPDATE users SET updated_at = now() WHERE age = @age AND deleted_at IS NULL;
@@ -1,20 +1,84 @@
 package repository
 
 import (
+	"context"
+
 	"github.com/raaaaaaaay86/go-persistence-extension/contract"
 	"github.com/raaaaaaaay86/go-persistence-extension/gorme"
 	"github.com/raaaaaaaay86/go-persistence-extension/gorme/entity"
 	"gorm.io/gorm"
 )
 
+// UserRepository is the repository of entity.User. CursorPaginated is enabled by
+// WithCursorSecret and Queries is loaded by WithNamedQueries, otherwise they are nil.
 type UserRepository struct {
 	db *gorm.DB
 	contract.Ultimate[entity.User, uint]
+	contract.Searcher[entity.User]
+	contract.CursorPaginated[entity.User]
+	Queries *gorme.NamedQueryRepository
 }
 
+// NewUserRepository returns the UserRepository without the optional features,
+// see NewUserRepositoryWith.
 func NewUserRepository(db *gorm.DB) *UserRepository {
 	return &UserRepository{
-		db: db,
+		db:       db,
 		Ultimate: gorme.NewEagerUltimateRepository[entity.User, uint](db),
+		Searcher: gorme.NewSearchRepository[entity.User](db, gorme.SearchConfig{}),
+	}
+}
+
+// UserRepositoryOption enables an optional feature of the UserRepository.
+type UserRepositoryOption func(r *UserRepository) error
+
+// WithCursorSecret enables the cursor pagination, which signs the cursors by secret.
+func WithCursorSecret(secret []byte) UserRepositoryOption {
+	return func(r *UserRepository) error {
+		cursors, err := gorme.NewEagerCursorRepository[entity.User](r.db, gorme.CursorConfig{Secret: secret})
+		if err != nil {
+			return err
+		}
+		r.CursorPaginated = cursors
+		return nil
 	}
 }
+
+// WithNamedQueries loads the named queries of queries/*.sql.
+func WithNamedQueries() UserRepositoryOption {
+	return func(r *UserRepository) error {
+		named, err := gorme.NewNamedQueryRepository(r.db, queries)
+		if err != nil {
+			return err
+		}
+		r.Queries = named
+		return nil
+	}
+}
+
+// NewUserRepositoryWith returns the UserRepository with the features of opts, or
+// the error of the first feature failed to be enabled.
+func NewUserRepositoryWith(db *gorm.DB, opts ...UserRepositoryOption) (*UserRepository, error) {
+	r := NewUserRepository(db)
+	for _, opt := range opts {
+		if err := opt(r); err != nil {
+			return nil, err
+		}
+	}
+	return r, nil
+}
+
+// DB implements gorme.Connector.
+func (r *UserRepository) DB() *gorm.DB {
+	return r.db
+}
+
+// UsersByMinAge runs the UsersByMinAge query of queries/user.sql.
+func (r *UserRepository) UsersByMinAge(ctx context.Context, minAge int) ([]*entity.User, error) {
+	return gorme.QueryMany[entity.User](ctx, r.Queries, "UsersByMinAge", struct{ MinAge int }{minAge})
+}
+
+// UserByUsername runs the UserByUsername query of queries/user.sql.
+func (r *UserRepository) UserByUsername(ctx context.Context, username string) (*entity.User, error) {
+	return gorme.QueryOne[entity.User](ctx, r.Queries, "UserByUsername", map[string]any{"username": username})
+}
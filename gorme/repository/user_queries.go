@@ -0,0 +1,20 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/raaaaaaaay86/go-persistence-extension/gorme/entity"
+)
+
+// UserQueries is implemented by NewUserQueries in user_queries_gen.go.
+//
+//go:generate go run ../../cmd/gpe gen -interface UserQueries
+type UserQueries interface {
+	FindByUsernameAndAgeGreaterThan(ctx context.Context, username string, age int) ([]*entity.User, error)
+	FindByAgeBetweenOrderByBirthdayDesc(ctx context.Context, from int, to int) ([]*entity.User, error)
+	FindByEmailEndingWithOrAgeIn(ctx context.Context, domain string, ages []int) ([]*entity.User, error)
+	GetByUsername(ctx context.Context, username string) (*entity.User, error)
+	CountByAgeGreaterThanEqual(ctx context.Context, age int) (int64, error)
+	ExistsByEmail(ctx context.Context, email string) (bool, error)
+	DeleteByUsernameStartingWith(ctx context.Context, prefix string) (int64, error)
+}
@@ -0,0 +1,66 @@
+// Code generated by gpe gen. DO NOT EDIT.
+
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/raaaaaaaay86/go-persistence-extension/contract"
+	"github.com/raaaaaaaay86/go-persistence-extension/gorme"
+	"github.com/raaaaaaaay86/go-persistence-extension/gorme/entity"
+)
+
+// gpeUserQueries implements UserQueries.
+type gpeUserQueries struct {
+	repo gorme.Connector
+}
+
+// NewUserQueries returns the UserQueries running on the database of repo.
+func NewUserQueries(repo gorme.Connector) UserQueries {
+	return &gpeUserQueries{repo: repo}
+}
+
+func (q *gpeUserQueries) FindByUsernameAndAgeGreaterThan(ctx context.Context, username string, age int) ([]*entity.User, error) {
+	spec := contract.And(
+		contract.Eq("username", username),
+		contract.Gt("age", age),
+	)
+	return gorme.FindBySpec[entity.User](ctx, q.repo, spec, contract.FindOptions{})
+}
+
+func (q *gpeUserQueries) FindByAgeBetweenOrderByBirthdayDesc(ctx context.Context, from int, to int) ([]*entity.User, error) {
+	spec := contract.Between("age", from, to)
+	return gorme.FindBySpec[entity.User](ctx, q.repo, spec, contract.FindOptions{Sort: []contract.Sort{contract.Desc("birthday")}})
+}
+
+func (q *gpeUserQueries) FindByEmailEndingWithOrAgeIn(ctx context.Context, domain string, ages []int) ([]*entity.User, error) {
+	spec := contract.Or(
+		contract.Suffix("email", domain),
+		contract.In("age", ages...),
+	)
+	return gorme.FindBySpec[entity.User](ctx, q.repo, spec, contract.FindOptions{})
+}
+
+func (q *gpeUserQueries) GetByUsername(ctx context.Context, username string) (*entity.User, error) {
+	spec := contract.Eq("username", username)
+	return gorme.GetBySpec[entity.User](ctx, q.repo, spec)
+}
+
+func (q *gpeUserQueries) CountByAgeGreaterThanEqual(ctx context.Context, age int) (int64, error) {
+	spec := contract.Gte("age", age)
+	return gorme.CountBySpec[entity.User](ctx, q.repo, spec)
+}
+
+func (q *gpeUserQueries) ExistsByEmail(ctx context.Context, email string) (bool, error) {
+	spec := contract.Eq("email", email)
+	return gorme.ExistsBySpec[entity.User](ctx, q.repo, spec)
+}
+
+func (q *gpeUserQueries) DeleteByUsernameStartingWith(ctx context.Context, prefix string) (int64, error) {
+	if prefix == "" {
+		return 0, errors.New("DeleteByUsernameStartingWith: empty prefix matches every row")
+	}
+	spec := contract.Prefix("username", prefix)
+	return gorme.DeleteBySpec[entity.User](ctx, q.repo, spec)
+}
@@ -0,0 +1,86 @@
+package gorme
+
+import (
+	"context"
+
+	"github.com/raaaaaaaay86/go-persistence-extension/contract"
+	"github.com/raaaaaaaay86/go-persistence-extension/gorme/macro"
+	"gorm.io/gorm"
+)
+
+var _ = contract.Searcher[any](&SearchRepository[any]{})
+
+type SearchMode string
+
+const (
+	// SearchPlain ignores the punctuation of the query and requires all terms.
+	SearchPlain SearchMode = "plainto_tsquery"
+	// SearchWeb supports the web search syntax: "quoted phrase", or, -exclude.
+	SearchWeb SearchMode = "websearch_to_tsquery"
+	// SearchPhrase requires the terms to be adjacent.
+	SearchPhrase SearchMode = "phraseto_tsquery"
+	// SearchRaw parses the query as tsquery syntax: cat & (dog | mouse).
+	SearchRaw SearchMode = "to_tsquery"
+)
+
+// SearchConfig configures the full-text search of SearchRepository.
+//
+// - Configuration: the PostgreSQL text search configuration. Default is "simple".
+// - Mode: how the query is parsed, one of the SearchMode constants. Default is SearchPlain.
+// - VectorColumn: a stored (generated) tsvector column to search instead of the entity fields.
+//
+//	ALTER TABLE users ADD COLUMN search_vector tsvector
+//		GENERATED ALWAYS AS (to_tsvector('english', username || ' ' || email)) STORED;
+type SearchConfig struct {
+	Configuration string
+	Mode          SearchMode
+	VectorColumn  string
+}
+
+type SearchRepository[T any] struct {
+	db     *gorm.DB
+	config SearchConfig
+}
+
+func NewSearchRepository[T any](db *gorm.DB, config SearchConfig) *SearchRepository[T] {
+	if config.Configuration == "" {
+		config.Configuration = "simple"
+	}
+	if config.Mode == "" {
+		config.Mode = SearchPlain
+	}
+	return &SearchRepository[T]{db, config}
+}
+
+// DB implements Connector.
+func (s *SearchRepository[T]) DB() *gorm.DB {
+	return s.db
+}
+
+// Search implements contract.Searcher.
+//
+// Search() will return records matched by the full-text query with pagination, ordered
+// by ts_rank. The searched columns are the non-zero string fields of entity, unless
+// SearchConfig.VectorColumn is set.
+//
+//	// Search users which username or email matches "jordan"
+//	results, err := Search(ctx, User{Username: mark.TargetString, Email: mark.TargetString}, "jordan", 1, 10)
+func (s *SearchRepository[T]) Search(
+	ctx context.Context,
+	entity T,
+	query string,
+	page int,
+	pageSize int,
+) (*contract.Pagination[T], error) {
+	return macro.Search(
+		ctx,
+		s.db,
+		entity,
+		query,
+		s.config.Configuration,
+		string(s.config.Mode),
+		s.config.VectorColumn,
+		page,
+		pageSize,
+	)
+}
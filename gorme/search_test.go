@@ -0,0 +1,70 @@
+package gorme_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/raaaaaaaay86/go-persistence-extension/gorme"
+	"github.com/raaaaaaaay86/go-persistence-extension/gorme/entity"
+	"github.com/raaaaaaaay86/go-persistence-extension/gorme/repository"
+	"github.com/raaaaaaaay86/go-persistence-extension/gorme/util"
+	"github.com/raaaaaaaay86/go-persistence-extension/mark"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/gorm"
+)
+
+type SearchOperationTestSuite struct {
+	suite.Suite
+	UserRepository *repository.UserRepository
+}
+
+func (s *SearchOperationTestSuite) SetupTest() {
+	if err := s.Setup(); err != nil {
+		s.T().Fatalf("failed to setup SearchOperationTestSuite: %s", err.Error())
+	}
+}
+
+func (s *SearchOperationTestSuite) Setup() error {
+	db, err := util.CreateGormPostgreSqlConnection(&gorm.Config{TranslateError: true})
+	if err != nil {
+		return err
+	}
+
+	s.UserRepository = repository.NewUserRepository(db.Debug())
+
+	return nil
+}
+
+func (s *SearchOperationTestSuite) Test_Search() {
+	ctx := context.Background()
+	target := entity.User{Username: mark.TargetString, Email: mark.TargetString}
+
+	s.T().Log("Test_Search: Search users by username")
+	results, err := s.UserRepository.Search(ctx, target, "user1", 1, 10)
+	assert.NoError(s.T(), err)
+	assert.NotEmpty(s.T(), results.Results)
+	assert.Equal(s.T(), int64(len(results.Results)), results.TotalCount)
+	for _, user := range results.Results {
+		assert.Equal(s.T(), "user1", user.Username)
+	}
+
+	s.T().Log("Test_Search: Search users by a non-exist word")
+	results, err = s.UserRepository.Search(ctx, target, "non-exist", 1, 10)
+	assert.NoError(s.T(), err)
+	assert.Empty(s.T(), results.Results)
+	assert.Equal(s.T(), int64(0), results.TotalCount)
+
+	s.T().Log("Test_Search: Search without a target field")
+	_, err = s.UserRepository.Search(ctx, entity.User{}, "user1", 1, 10)
+	assert.Error(s.T(), err)
+
+	s.T().Log("Test_Search: Search with an unknown search mode")
+	searcher := gorme.NewSearchRepository[entity.User](s.UserRepository.DB(), gorme.SearchConfig{Mode: "now(); --"})
+	_, err = searcher.Search(ctx, target, "user1", 1, 10)
+	assert.Error(s.T(), err)
+}
+
+func TestSearchOperationTestSuite(t *testing.T) {
+	suite.Run(t, new(SearchOperationTestSuite))
+}
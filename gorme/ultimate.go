@@ -1,6 +1,8 @@
 package gorme
 
 import (
+	"context"
+
 	"github.com/raaaaaaaay86/go-persistence-extension/contract"
 	"gorm.io/gorm"
 	"gorm.io/gorm/clause"
@@ -9,21 +11,37 @@ import (
 var _ = contract.Ultimate[any, uint](&UltimateRepository[any, uint]{})
 
 type UltimateRepository[T any, Q contract.Identifier] struct {
+	db *gorm.DB
 	contract.Basic[T, Q]
 	contract.Paginated[T, Q]
+	contract.Aggregator[T]
 }
 
 func NewUltimateRepository[T any, Q contract.Identifier](
 	db *gorm.DB,
+	opts ...Option,
 ) *UltimateRepository[T,Q] {
 	return &UltimateRepository[T, Q]{
-		NewBasicRepository[T, Q](db),
-		NewPaginationRepository[T, Q](db),
+		db,
+		NewBasicRepository[T, Q](db, opts...),
+		NewPaginationRepository[T, Q](db, opts...),
+		NewAggregationRepository[T](db),
 	}
 }
 
 func NewEagerUltimateRepository[T any, Q contract.Identifier](
 	db *gorm.DB,
+	opts ...Option,
 ) *UltimateRepository[T, Q] {
-	return NewUltimateRepository[T, Q](db.Preload(clause.Associations))
+	return NewUltimateRepository[T, Q](db.Preload(clause.Associations), opts...)
+}
+
+// DB implements Connector.
+func (u *UltimateRepository[T, Q]) DB() *gorm.DB {
+	return u.db
+}
+
+// CountBy implements contract.Basic and contract.Aggregator.
+func (u *UltimateRepository[T, Q]) CountBy(ctx context.Context, query contract.QueryMap) (int64, error) {
+	return u.Basic.CountBy(ctx, query)
 }
@@ -1,6 +1,8 @@
 package util
 
 import (
+	"database/sql"
+	"database/sql/driver"
 	"errors"
 	"fmt"
 	"reflect"
@@ -8,6 +10,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/raaaaaaaay86/go-persistence-extension/contract"
 	"gorm.io/gorm"
 )
 
@@ -19,7 +22,20 @@ type FieldInformation struct {
 	ReflectType reflect.Type
 }
 
-func ParseTargetField(entity any, targetType reflect.Type) (*FieldInformation, error) {
+// ParseTargetField returns the target field of the selector, which is the column
+// of a contract.ColumnSelector or a column name, or else the only non-zero field
+// of the entity which has the target type.
+func ParseTargetField(entity contract.Selector, targetType reflect.Type) (*FieldInformation, error) {
+	if info, ok, err := selectedField(entity); ok || err != nil {
+		if err != nil {
+			return nil, err
+		}
+		if info.ReflectType != nil && info.ReflectType != targetType {
+			return nil, fmt.Errorf("selected column (%s) has type (%s), expected field type (%s)", info.ColumnName, info.ReflectType, targetType)
+		}
+		return info, nil
+	}
+
 	fields, err := ParseNoneZeroFields(reflect.ValueOf(entity))
 	if err != nil {
 		return nil, err
@@ -31,9 +47,9 @@ func ParseTargetField(entity any, targetType reflect.Type) (*FieldInformation, e
 
 	var targetField *FieldInformation
 	for i, count := 0, 0; i < len(fields); i++ {
-		if fields[i].ReflectType.Name() == targetType.Name() {
+		if fields[i].ReflectType == targetType {
 			if count > 0 {
-				return nil, errors.New("ambiguous target field. there are at most one target field allowed")
+				return nil, errors.New("ambiguous target field. there are at most one target field allowed, select the field with gorme.Field instead")
 			}
 			targetField = fields[i]
 			count++
@@ -47,6 +63,49 @@ func ParseTargetField(entity any, targetType reflect.Type) (*FieldInformation, e
 	return targetField, nil
 }
 
+// ParseMarkedField returns the selected field like ParseTargetField, but the only
+// non-zero field of the entity is selected regardless of its type.
+func ParseMarkedField(entity contract.Selector) (*FieldInformation, error) {
+	if info, ok, err := selectedField(entity); ok || err != nil {
+		return info, err
+	}
+
+	fields, err := ParseNoneZeroFields(reflect.ValueOf(entity))
+	if err != nil {
+		return nil, err
+	}
+
+	switch len(fields) {
+	case 0:
+		return nil, errors.New("no target field specified")
+	case 1:
+		return fields[0], nil
+	default:
+		return nil, errors.New("ambiguous target field. there are at most one target field allowed, select the field with gorme.Field instead")
+	}
+}
+
+var columnNamePattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// selectedField returns the field of a selector naming its column directly. The
+// column name is interpolated into SQL, so it must be a plain identifier.
+func selectedField(selector contract.Selector) (*FieldInformation, bool, error) {
+	var info *FieldInformation
+	switch selector := selector.(type) {
+	case contract.ColumnSelector:
+		info = &FieldInformation{ColumnName: selector.ColumnName(), ReflectType: selector.ColumnType()}
+	case string:
+		info = &FieldInformation{ColumnName: selector}
+	default:
+		return nil, false, nil
+	}
+
+	if !columnNamePattern.MatchString(info.ColumnName) {
+		return nil, false, fmt.Errorf("invalid column name (%s)", info.ColumnName)
+	}
+	return info, true, nil
+}
+
 func ParseNoneZeroFields(v reflect.Value) ([]*FieldInformation, error) {
 	if v.Kind() == reflect.Ptr {
 		v = v.Elem()
@@ -73,12 +132,7 @@ func ParseNoneZeroFields(v reflect.Value) ([]*FieldInformation, error) {
 			ReflectType: reflect.TypeOf(value.Interface()),
 		}
 
-		tagInfo := ParseGormTag(field.Tag.Get("gorm"))
-		if tagInfo != nil {
-			info.ColumnName = tagInfo.ColumnName
-		} else {
-			info.ColumnName = ToSnakeCase(info.Name)
-		}
+		info.ColumnName = ColumnName(field)
 
 		infos = append(infos, info)
 	}
@@ -86,21 +140,90 @@ func ParseNoneZeroFields(v reflect.Value) ([]*FieldInformation, error) {
 	return infos, nil
 }
 
+// ColumnName returns the column name of the field by its gorm tag or its snake case name.
+func ColumnName(field *reflect.StructField) string {
+	if tagInfo := ParseGormTag(field.Tag.Get("gorm")); tagInfo != nil {
+		return tagInfo.ColumnName
+	}
+	return ToSnakeCase(field.Name)
+}
+
 type GormTagInfo struct {
 	ColumnName string
 }
 
 func ParseGormTag(tag string) *GormTagInfo {
 	info := &GormTagInfo{}
+	for _, attr := range strings.Split(tag, ";") {
+		attr = strings.TrimSpace(attr)
+		split := strings.SplitN(attr, ":", 2)
+		switch strings.ToLower(split[0]) {
+		case "column":
+			if len(split) == 2 {
+				info.ColumnName = strings.TrimSpace(split[1])
+			}
+		}
+	}
+	if info.ColumnName == "" {
+		return nil
+	}
+	return info
+}
+
+// FilterTagInfo describes the gpe tag of a filter field.
+//
+//	type UserFilter struct {
+//		Username *string `gpe:"op=like,match=prefix"`
+//		MinAge   *int    `gpe:"column=age,op=gte"`
+//		Theme    *string `gpe:"column=settings,path=display.theme"`
+//		Internal string  `gpe:"-"`
+//	}
+type FilterTagInfo struct {
+	ColumnName string
+	Operator   string
+	Match      string
+	Path       []string
+	Ignored    bool
+}
+
+// ParseFilterTag parses the gpe tag. It returns nil when the tag is empty.
+func ParseFilterTag(tag string) (*FilterTagInfo, error) {
+	tag = strings.TrimSpace(tag)
+	if tag == "" {
+		return nil, nil
+	}
+
+	info := &FilterTagInfo{}
+	if tag == "-" {
+		info.Ignored = true
+		return info, nil
+	}
+
 	for _, attr := range strings.Split(tag, ",") {
 		attr = strings.TrimSpace(attr)
-		split := strings.Split(attr, ":")
-		switch split[0] {
+		if attr == "" {
+			continue
+		}
+		split := strings.SplitN(attr, "=", 2)
+		if len(split) != 2 {
+			return nil, fmt.Errorf("invalid gpe tag attribute (%s)", attr)
+		}
+		key, value := strings.TrimSpace(split[0]), strings.TrimSpace(split[1])
+		switch key {
 		case "column":
-			info.ColumnName = split[1]
+			info.ColumnName = value
+		case "op":
+			info.Operator = value
+		case "match":
+			info.Match = value
+		case "path":
+			info.Path = strings.Split(value, ".")
+		default:
+			return nil, fmt.Errorf("unknown gpe tag attribute (%s)", key)
 		}
 	}
-	return nil
+
+	return info, nil
 }
 
 func ToSnakeCase(str string) string {
@@ -112,8 +235,16 @@ func ToSnakeCase(str string) string {
 }
 
 func FlattenStruct(v reflect.Value) ([]*reflect.StructField, []*reflect.Value) {
+	fields, values, _ := FlattenStructPaths(v)
+	return fields, values
+}
+
+// FlattenStructPaths is like FlattenStruct but also returns the dot separated
+// path of each field from v, e.g. Model.ID for the ID of an embedded gorm.Model.
+func FlattenStructPaths(v reflect.Value) ([]*reflect.StructField, []*reflect.Value, []string) {
 	var fields []*reflect.StructField
 	var values []*reflect.Value
+	var paths []string
 
 	for i := 0; i < v.NumField(); i++ {
 		structField := v.Type().Field(i)
@@ -129,17 +260,36 @@ func FlattenStruct(v reflect.Value) ([]*reflect.StructField, []*reflect.Value) {
 				reflect.TypeOf(gorm.DeletedAt{}).Name():
 				fields = append(fields, &structField)
 				values = append(values, &value)
+				paths = append(paths, structField.Name)
 			default:
-				fs, vs := FlattenStruct(v.Field(i))
+				// A struct mapped to a single column, e.g. sql.NullString, is not flattened.
+				if isColumnValue(structField.Type) {
+					fields = append(fields, &structField)
+					values = append(values, &value)
+					paths = append(paths, structField.Name)
+					continue
+				}
+
+				fs, vs, ps := FlattenStructPaths(v.Field(i))
 				fields = append(fields, fs...)
 				values = append(values, vs...)
+				for _, path := range ps {
+					paths = append(paths, structField.Name+"."+path)
+				}
 			}
 			continue
 		}
 
 		fields = append(fields, &structField)
 		values = append(values, &value)
+		paths = append(paths, structField.Name)
 	}
 
-	return fields, values
+	return fields, values, paths
+}
+
+func isColumnValue(t reflect.Type) bool {
+	valuer := reflect.TypeOf((*driver.Valuer)(nil)).Elem()
+	scanner := reflect.TypeOf((*sql.Scanner)(nil)).Elem()
+	return t.Implements(valuer) || reflect.PointerTo(t).Implements(scanner)
 }
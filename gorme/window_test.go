@@ -0,0 +1,114 @@
+package gorme_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/raaaaaaaay86/go-persistence-extension/contract"
+	"github.com/raaaaaaaay86/go-persistence-extension/gorme"
+	"github.com/raaaaaaaay86/go-persistence-extension/gorme/entity"
+	"github.com/raaaaaaaay86/go-persistence-extension/gorme/util"
+	"github.com/raaaaaaaay86/go-persistence-extension/mark"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/gorm"
+)
+
+type WindowOperationTestSuite struct {
+	suite.Suite
+	UserRepository contract.Ultimate[entity.User, uint]
+}
+
+func (s *WindowOperationTestSuite) SetupTest() {
+	if err := s.Setup(); err != nil {
+		s.T().Fatalf("failed to setup WindowOperationTestSuite: %s", err.Error())
+	}
+}
+
+func (s *WindowOperationTestSuite) Setup() error {
+	db, err := util.CreateGormPostgreSqlConnection(&gorm.Config{TranslateError: true})
+	if err != nil {
+		return err
+	}
+
+	clock := contract.FixedClock{Time: time.Date(2000, 7, 15, 12, 0, 0, 0, time.UTC)}
+	s.UserRepository = gorme.NewUltimateRepository[entity.User, uint](db.Debug(), gorme.WithClock(clock))
+
+	return nil
+}
+
+func (s *WindowOperationTestSuite) Test_FindTimeWithin() {
+	ctx := context.Background()
+	target := entity.User{Birthday: mark.TargetTime}
+
+	s.T().Log("Test_FindTimeWithin: Find users born in the 60 days before 2000-07-15")
+	users, err := s.UserRepository.FindTimeWithin(ctx, target, 60*24*time.Hour, -1, contract.Asc("birthday"))
+	assert.NoError(s.T(), err)
+	assert.Len(s.T(), users, 2)
+	for _, user := range users {
+		assert.True(s.T(), user.Birthday.After(time.Date(2000, 5, 16, 0, 0, 0, 0, time.UTC)))
+	}
+
+	pagination, err := s.UserRepository.PFindTimeWithin(ctx, target, 60*24*time.Hour, 1, 1)
+	assert.NoError(s.T(), err)
+	assert.Len(s.T(), pagination.Results, 1)
+	assert.Equal(s.T(), int64(2), pagination.TotalCount)
+}
+
+func (s *WindowOperationTestSuite) Test_FindTimeInWindow() {
+	ctx := context.Background()
+	target := entity.User{Birthday: mark.TargetTime}
+
+	s.T().Log("Test_FindTimeInWindow: Find users born in July 2000")
+	users, err := s.UserRepository.FindTimeInWindow(ctx, target, contract.ThisMonth(time.UTC), -1)
+	assert.NoError(s.T(), err)
+	assert.Len(s.T(), users, 1)
+	assert.Equal(s.T(), "user5", users[0].Username)
+
+	s.T().Log("Test_FindTimeInWindow: Find users born in June 2000")
+	pagination, err := s.UserRepository.PFindTimeInWindow(ctx, target, contract.LastMonth(time.UTC), 1, 10)
+	assert.NoError(s.T(), err)
+	assert.Equal(s.T(), int64(1), pagination.TotalCount)
+	assert.Equal(s.T(), "user4", pagination.Results[0].Username)
+}
+
+func TestWindowOperationTestSuite(t *testing.T) {
+	suite.Run(t, new(WindowOperationTestSuite))
+}
+
+func TestCalendarWindow(t *testing.T) {
+	taipei := time.FixedZone("Asia/Taipei", 8*60*60)
+	// 2024-03-31 20:00 in Taipei
+	now := time.Date(2024, 3, 31, 12, 0, 0, 0, time.UTC)
+
+	start, end, err := contract.Today(taipei).Range(now)
+	assert.NoError(t, err)
+	assert.True(t, start.Equal(time.Date(2024, 3, 31, 0, 0, 0, 0, taipei)))
+	assert.True(t, end.Equal(time.Date(2024, 4, 1, 0, 0, 0, 0, taipei)))
+
+	start, end, err = contract.ThisWeek(taipei).Range(now)
+	assert.NoError(t, err)
+	assert.True(t, start.Equal(time.Date(2024, 3, 25, 0, 0, 0, 0, taipei)))
+	assert.True(t, end.Equal(time.Date(2024, 4, 1, 0, 0, 0, 0, taipei)))
+
+	start, end, err = contract.LastMonth(taipei).Range(now)
+	assert.NoError(t, err)
+	assert.True(t, start.Equal(time.Date(2024, 2, 1, 0, 0, 0, 0, taipei)))
+	assert.True(t, end.Equal(time.Date(2024, 3, 1, 0, 0, 0, 0, taipei)))
+
+	// It's already April at UTC+13.
+	start, _, err = contract.ThisMonth(time.FixedZone("UTC+13", 13*60*60)).Range(now)
+	assert.NoError(t, err)
+	assert.Equal(t, time.April, start.Month())
+
+	start, end, err = contract.Last(7 * 24 * time.Hour).Range(now)
+	assert.NoError(t, err)
+	assert.Equal(t, 7*24*time.Hour, end.Sub(start))
+
+	_, _, err = contract.Last(0).Range(now)
+	assert.Error(t, err)
+
+	_, _, err = contract.CalendarWindow{Period: "fortnight"}.Range(now)
+	assert.Error(t, err)
+}
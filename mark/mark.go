@@ -1,8 +1,10 @@
 package mark
 
 import (
+	"database/sql"
 	"time"
 
+	"github.com/raaaaaaaay86/go-persistence-extension/contract"
 	"gorm.io/gorm"
 )
 
@@ -22,4 +24,28 @@ var TargetUint32 = uint32(1)
 var TargetUint64 = uint64(1)
 
 var TargetFloat32 = float32(1)
-var TargetFloat64 = float64(1)
\ No newline at end of file
+var TargetFloat64 = float64(1)
+
+var TargetString = "*"
+
+var TargetNullString = sql.NullString{String: TargetString, Valid: true}
+var TargetNullInt32 = sql.NullInt32{Int32: 1, Valid: true}
+var TargetNullInt64 = sql.NullInt64{Int64: 1, Valid: true}
+var TargetNullFloat64 = sql.NullFloat64{Float64: 1, Valid: true}
+var TargetNullBool = sql.NullBool{Bool: true, Valid: true}
+var TargetNullTime = sql.NullTime{Time: TargetTime, Valid: true}
+
+// TargetPtr returns the mark value of a pointer field, which is any non-nil pointer.
+//
+//	user := User{Nickname: mark.TargetPtr[string]()}
+func TargetPtr[V any]() *V {
+	return new(V)
+}
+
+// Target returns the mark value of any numeric type, including named types.
+//
+//	type Cents int64
+//	invoice := Invoice{Amount: mark.Target[Cents]()}
+func Target[V contract.Number]() V {
+	return V(1)
+}
@@ -9,4 +9,17 @@ import (
 
 func TestMarks(t *testing.T) {
 	assert.False(t, mark.TargetTime.IsZero())
-}
\ No newline at end of file
+}
+
+func TestTarget(t *testing.T) {
+	type Cents int64
+
+	assert.Equal(t, Cents(1), mark.Target[Cents]())
+	assert.Equal(t, uint32(1), mark.Target[uint32]())
+}
+
+func TestTargetPtr(t *testing.T) {
+	assert.NotNil(t, mark.TargetPtr[string]())
+	assert.True(t, mark.TargetNullString.Valid)
+	assert.False(t, mark.TargetNullTime.Time.IsZero())
+}
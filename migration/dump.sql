@@ -6,6 +6,8 @@ CREATE TABLE IF NOT EXISTS users(
   email VARCHAR(100) NOT NULL,
   age INT NOT NULL,
   birthday DATE NOT NULL,
+  roles TEXT[] DEFAULT '{}',
+  nickname VARCHAR(100),
   created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
   updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
   deleted_at TIMESTAMP WITH TIME ZONE
@@ -22,4 +24,61 @@ INSERT INTO users (username, email, age, birthday) VALUES ('user8', 'test@mail.c
 INSERT INTO users (username, email, age, birthday) VALUES ('user9', 'test@mail.com', 23, '2000-11-11');
 INSERT INTO users (username, email, age, birthday) VALUES ('user10', 'test@mail.com', 20, '2000-12-12');
 
+UPDATE users SET roles = '{admin,editor}' WHERE username = 'user1';
+UPDATE users SET roles = '{editor}' WHERE username IN ('user2', 'user3');
+UPDATE users SET roles = '{viewer}' WHERE username = 'user4';
+
+UPDATE users SET nickname = 'one' WHERE username = 'user1';
+UPDATE users SET nickname = 'two' WHERE username = 'user2';
+
+CREATE TABLE IF NOT EXISTS profiles(
+  id serial PRIMARY KEY,
+  user_id INT NOT NULL REFERENCES users(id),
+  settings JSONB NOT NULL DEFAULT '{}',
+  created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+  updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+  deleted_at TIMESTAMP WITH TIME ZONE
+);
+
+INSERT INTO profiles (user_id, settings) VALUES (1, '{"theme": "dark", "level": 3, "tags": ["vip", "beta"], "notification": {"email": true}}');
+INSERT INTO profiles (user_id, settings) VALUES (2, '{"theme": "light", "level": 1, "tags": ["beta"], "notification": {"email": false}}');
+INSERT INTO profiles (user_id, settings) VALUES (3, '{"theme": "dark", "level": 5, "tags": [], "notification": {"email": true}}');
+INSERT INTO profiles (user_id, settings) VALUES (4, '{"theme": "light", "level": 2}');
+
+CREATE TABLE IF NOT EXISTS orders(
+  id serial PRIMARY KEY,
+  user_id INT NOT NULL REFERENCES users(id),
+  amount NUMERIC(10, 2) NOT NULL,
+  status VARCHAR(20) NOT NULL,
+  created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+  updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+  deleted_at TIMESTAMP WITH TIME ZONE
+);
+
+INSERT INTO orders (user_id, amount, status) VALUES (1, 150, 'paid');
+INSERT INTO orders (user_id, amount, status) VALUES (1, 30, 'paid');
+INSERT INTO orders (user_id, amount, status) VALUES (1, 200, 'paid');
+INSERT INTO orders (user_id, amount, status) VALUES (2, 120, 'cancelled');
+INSERT INTO orders (user_id, amount, status) VALUES (3, 80, 'paid');
+
+CREATE TABLE IF NOT EXISTS teams(
+  id serial PRIMARY KEY,
+  name VARCHAR(100) NOT NULL,
+  created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+  updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+  deleted_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE TABLE IF NOT EXISTS user_teams(
+  user_id INT NOT NULL REFERENCES users(id),
+  team_id INT NOT NULL REFERENCES teams(id),
+  PRIMARY KEY (user_id, team_id)
+);
+
+INSERT INTO teams (name) VALUES ('backend');
+INSERT INTO teams (name) VALUES ('frontend');
+INSERT INTO user_teams (user_id, team_id) VALUES (1, 1);
+INSERT INTO user_teams (user_id, team_id) VALUES (2, 1);
+INSERT INTO user_teams (user_id, team_id) VALUES (2, 2);
+
 COMMIT;
\ No newline at end of file